@@ -65,7 +65,7 @@ func generateReadmeDocs(readmePath string) error {
 
 	// Create toolset group with mock clients
 	// For docs generation, we don't need real permission checking, so pass nil
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, nil)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, nil, github.OutputFormatJSON, nil, nil, nil)
 
 	// Generate toolsets documentation
 	toolsetsDoc := generateToolsetsDoc(tsg)
@@ -304,7 +304,7 @@ func generateRemoteToolsetsDoc() string {
 
 	// Create toolset group with mock clients
 	// For docs generation, we don't need real permission checking, so pass nil
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, nil)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, nil, github.OutputFormatJSON, nil, nil, nil)
 
 	// Generate table header
 	buf.WriteString("| Name           | Description                                      | API URL                                               | 1-Click Install (VS Code)                                                                                                                                                                                                 | Read-only Link                                                                                                 | 1-Click Read-only Install (VS Code)                                                                                                                                                                                                 |\n")
@@ -45,6 +45,14 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			var tools, excludeTools []string
+			if err := viper.UnmarshalKey("tools", &tools); err != nil {
+				return fmt.Errorf("failed to unmarshal tools: %w", err)
+			}
+			if err := viper.UnmarshalKey("exclude_tools", &excludeTools); err != nil {
+				return fmt.Errorf("failed to unmarshal exclude-tools: %w", err)
+			}
+
 			// Parse allowed repos from environment variable
 			var allowedRepos []string
 			allowedReposStr := viper.GetString("allowed_repos")
@@ -57,17 +65,24 @@ var (
 			}
 
 			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				ContentWindowSize:    viper.GetInt("content-window-size"),
-				AllowedRepos:         allowedRepos,
+				Version:               version,
+				Host:                  viper.GetString("host"),
+				Token:                 token,
+				EnabledToolsets:       enabledToolsets,
+				DynamicToolsets:       viper.GetBool("dynamic_toolsets"),
+				ReadOnly:              viper.GetBool("read-only"),
+				ExportTranslations:    viper.GetBool("export-translations"),
+				EnableCommandLogging:  viper.GetBool("enable-command-logging"),
+				LogFilePath:           viper.GetString("log-file"),
+				ContentWindowSize:     viper.GetInt("content-window-size"),
+				AllowedRepos:          allowedRepos,
+				OutputFormat:          viper.GetString("output_format"),
+				MaxConcurrentRequests: viper.GetInt("max_concurrent_requests"),
+				IdempotencyStorePath:  viper.GetString("idempotency_store"),
+				HideUnusableTools:     viper.GetBool("hide_unusable_tools"),
+				DynamicMaxActiveTools: viper.GetInt("dynamic_max_active_tools"),
+				Tools:                 tools,
+				ExcludeTools:          excludeTools,
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -82,6 +97,8 @@ func init() {
 
 	// Add global flags that will be shared by all commands
 	rootCmd.PersistentFlags().StringSlice("toolsets", github.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
+	rootCmd.PersistentFlags().StringSlice("tools", nil, "An optional comma separated list of tool names to allow from the enabled toolsets, supports globs such as get_*")
+	rootCmd.PersistentFlags().StringSlice("exclude-tools", nil, "An optional comma separated list of tool names to remove, supports globs such as delete_*")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
@@ -89,9 +106,16 @@ func init() {
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().String("output-format", "json", "Default format of read tool results: json, markdown, csv or table")
+	rootCmd.PersistentFlags().Int("max-concurrent-requests", 10, "Maximum number of concurrent requests per GitHub host, 0 for unlimited")
+	rootCmd.PersistentFlags().String("idempotency-store", "", "Path to a file to persist idempotency keys of create tools across restarts")
+	rootCmd.PersistentFlags().Bool("hide-unusable-tools", false, "Hide tools the token cannot use based on its scopes")
+	rootCmd.PersistentFlags().Int("dynamic-max-active-tools", 0, "Maximum number of active tools in dynamic mode, least recently used toolsets are disabled beyond it (0 for no limit)")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
+	_ = viper.BindPFlag("tools", rootCmd.PersistentFlags().Lookup("tools"))
+	_ = viper.BindPFlag("exclude_tools", rootCmd.PersistentFlags().Lookup("exclude-tools"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
@@ -99,6 +123,11 @@ func init() {
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("output_format", rootCmd.PersistentFlags().Lookup("output-format"))
+	_ = viper.BindPFlag("max_concurrent_requests", rootCmd.PersistentFlags().Lookup("max-concurrent-requests"))
+	_ = viper.BindPFlag("idempotency_store", rootCmd.PersistentFlags().Lookup("idempotency-store"))
+	_ = viper.BindPFlag("hide_unusable_tools", rootCmd.PersistentFlags().Lookup("hide-unusable-tools"))
+	_ = viper.BindPFlag("dynamic_max_active_tools", rootCmd.PersistentFlags().Lookup("dynamic-max-active-tools"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
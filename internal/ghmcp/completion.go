@@ -0,0 +1,164 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// completionHandler answers completion/complete requests over stdio. The server of mcp-go neither
+// handles these requests nor advertises the completions capability, so the handler sits between
+// the streams and the server like the rootsClient: it consumes the completion requests, answers
+// them with the Completer, and adds the capability to the response to initialize.
+type completionHandler struct {
+	completer *github.Completer
+
+	out *lockedWriter
+
+	mu           sync.Mutex
+	ctx          context.Context
+	initializeID json.RawMessage
+}
+
+func newCompletionHandler(completer *github.Completer) *completionHandler {
+	return &completionHandler{completer: completer, ctx: context.Background()}
+}
+
+// serverContext records the context of the stdio session, so that completions use the repository
+// context of the session. It is meant to be the context function of the stdio server.
+func (h *completionHandler) serverContext(ctx context.Context) context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ctx = ctx
+	return ctx
+}
+
+// wrap returns the streams the server should use in place of in and out.
+func (h *completionHandler) wrap(in io.Reader, out io.Writer) (io.Reader, io.Writer) {
+	h.out = &lockedWriter{w: out}
+	return interceptLines(in, h.intercept), h
+}
+
+// Write passes the messages of the server on to the client, adding the completions capability to
+// the response to initialize. The stdio server writes each message with a single call.
+func (h *completionHandler) Write(p []byte) (int, error) {
+	h.mu.Lock()
+	initializeID := h.initializeID
+	h.mu.Unlock()
+
+	message := p
+	if initializeID != nil {
+		if rewritten, ok := addCompletionsCapability(p, initializeID); ok {
+			h.mu.Lock()
+			h.initializeID = nil
+			h.mu.Unlock()
+			message = rewritten
+		}
+	}
+	if _, err := h.out.Write(message); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// addCompletionsCapability adds the completions capability to the response with the given ID.
+func addCompletionsCapability(line []byte, id json.RawMessage) ([]byte, bool) {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(line, &response); err != nil || !bytes.Equal(response["id"], id) || response["result"] == nil {
+		return nil, false
+	}
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(response["result"], &result); err != nil {
+		return nil, false
+	}
+	capabilities := map[string]json.RawMessage{}
+	if raw, ok := result["capabilities"]; ok {
+		if err := json.Unmarshal(raw, &capabilities); err != nil {
+			return nil, false
+		}
+	}
+	capabilities["completions"] = json.RawMessage(`{}`)
+
+	var err error
+	if result["capabilities"], err = json.Marshal(capabilities); err != nil {
+		return nil, false
+	}
+	if response["result"], err = json.Marshal(result); err != nil {
+		return nil, false
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, false
+	}
+	return append(data, '\n'), true
+}
+
+type completeParams struct {
+	Ref      github.CompletionRef `json:"ref"`
+	Argument struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"argument"`
+	Context struct {
+		Arguments map[string]string `json:"arguments"`
+	} `json:"context"`
+}
+
+// intercept inspects a message from the client and reports whether it was consumed.
+func (h *completionHandler) intercept(line []byte) bool {
+	var message struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(line, &message); err != nil {
+		return false
+	}
+
+	switch message.Method {
+	case string(mcp.MethodInitialize):
+		h.mu.Lock()
+		h.initializeID = message.ID
+		h.mu.Unlock()
+	case "completion/complete":
+		var id mcp.RequestId
+		if err := json.Unmarshal(message.ID, &id); err != nil {
+			return false
+		}
+		// Answered asynchronously, lookups must not hold up the other messages of the client
+		go h.complete(id, message.Params)
+		return true
+	}
+	return false
+}
+
+// complete answers a completion/complete request.
+func (h *completionHandler) complete(id mcp.RequestId, rawParams json.RawMessage) {
+	var response any
+	var params completeParams
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		response = mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, "invalid completion params: "+err.Error(), nil)
+	} else {
+		h.mu.Lock()
+		ctx := h.ctx
+		h.mu.Unlock()
+
+		result, err := h.completer.Complete(ctx, params.Ref, params.Argument.Name, params.Argument.Value, params.Context.Arguments)
+		if err != nil {
+			response = mcp.NewJSONRPCError(id, mcp.INTERNAL_ERROR, err.Error(), nil)
+		} else {
+			response = mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: id, Result: result}
+		}
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	_, _ = h.out.Write(append(data, '\n'))
+}
@@ -0,0 +1,75 @@
+package ghmcp
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CompletionHandler(t *testing.T) {
+	client := gogithub.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, []*gogithub.Branch{{Name: gogithub.Ptr("main")}, {Name: gogithub.Ptr("develop")}}),
+	))
+	getClient := func(context.Context) (*gogithub.Client, error) { return client, nil }
+	handler := newCompletionHandler(github.NewCompleter(getClient, nil, nil))
+
+	clientIn, clientWriter := io.Pipe()
+	clientReader, serverOut := io.Pipe()
+	in, out := handler.wrap(clientIn, serverOut)
+	serverLines := bufio.NewReader(in)
+	clientLines := bufio.NewReader(clientReader)
+
+	send := func(message string) {
+		go func() { _, _ = clientWriter.Write([]byte(message + "\n")) }()
+	}
+	respond := func(message string) {
+		go func() { _, _ = out.Write([]byte(message + "\n")) }()
+	}
+
+	t.Run("advertises the completions capability", func(t *testing.T) {
+		send(`{"jsonrpc":"2.0","id":"init","method":"initialize","params":{"capabilities":{}}}`)
+		line, err := serverLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, line, `"method":"initialize"`)
+
+		respond(`{"jsonrpc":"2.0","id":"init","result":{"protocolVersion":"2025-03-26","capabilities":{"tools":{"listChanged":true}}}}`)
+		line, err = clientLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":"init","result":{"protocolVersion":"2025-03-26","capabilities":{"tools":{"listChanged":true},"completions":{}}}}`, line)
+
+		// Other messages are passed through unchanged
+		respond(`{"jsonrpc":"2.0","id":1,"result":{}}`)
+		line, err = clientLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, `{"jsonrpc":"2.0","id":1,"result":{}}`+"\n", line)
+	})
+
+	t.Run("answers completion requests", func(t *testing.T) {
+		send(`{"jsonrpc":"2.0","id":2,"method":"completion/complete","params":{"ref":{"type":"ref/resource","uri":"repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"},"argument":{"name":"branch","value":"dev"},"context":{"arguments":{"owner":"octocat","repo":"hello-world"}}}}`)
+		line, err := clientLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"result":{"completion":{"values":["develop"],"total":1}}}`, line)
+
+		// The request is not forwarded to the server
+		send(`{"jsonrpc":"2.0","id":3,"method":"ping"}`)
+		line, err = serverLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, line, `"method":"ping"`)
+	})
+
+	t.Run("reports lookup errors", func(t *testing.T) {
+		send(`{"jsonrpc":"2.0","id":4,"method":"completion/complete","params":{"ref":{"type":"ref/resource","uri":"repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}"},"argument":{"name":"tag","value":""},"context":{"arguments":{"owner":"octocat","repo":"hello-world"}}}}`)
+		line, err := clientLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, line, `"id":4`)
+		assert.Contains(t, line, `"code":-32603`)
+		assert.Contains(t, line, "failed to list tags")
+	})
+}
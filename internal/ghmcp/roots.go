@@ -0,0 +1,137 @@
+package ghmcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// rootsRequestIDPrefix marks the IDs of the roots/list requests sent to the client, so that their
+// responses can be told apart from other messages.
+const rootsRequestIDPrefix = "github-mcp-server/roots/"
+
+// rootsClient requests the roots of the client over stdio. The stdio server of mcp-go cannot send
+// requests of its own, so the client sits between the streams and the server: it watches the
+// initialization for the roots capability, sends roots/list once the client is initialized or its
+// roots change, and consumes the responses before they reach the server.
+type rootsClient struct {
+	onRoots func([]mcp.Root)
+
+	out       *lockedWriter
+	supported atomic.Bool
+	nextID    atomic.Int64
+}
+
+func newRootsClient(onRoots func([]mcp.Root)) *rootsClient {
+	return &rootsClient{onRoots: onRoots}
+}
+
+// wrap returns the streams the server should use in place of in and out.
+func (c *rootsClient) wrap(in io.Reader, out io.Writer) (io.Reader, io.Writer) {
+	c.out = &lockedWriter{w: out}
+	return interceptLines(in, c.intercept), c.out
+}
+
+// interceptLines returns a reader of the messages of in that intercept does not consume. The
+// messages are read in the background, so intercept must not block on writing to the client.
+func interceptLines(in io.Reader, intercept func([]byte) bool) io.Reader {
+	pr, pw := io.Pipe()
+	go forwardLines(in, pw, intercept)
+	return pr
+}
+
+func forwardLines(in io.Reader, pw *io.PipeWriter, intercept func([]byte) bool) {
+	reader := bufio.NewReader(in)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && !intercept(line) {
+			if _, writeErr := pw.Write(line); writeErr != nil {
+				return
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				_ = pw.Close()
+			} else {
+				_ = pw.CloseWithError(err)
+			}
+			return
+		}
+	}
+}
+
+// intercept inspects a message from the client and reports whether it was consumed.
+func (c *rootsClient) intercept(line []byte) bool {
+	var message struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(line, &message); err != nil {
+		return false
+	}
+
+	switch message.Method {
+	case "":
+		var id string
+		if json.Unmarshal(message.ID, &id) != nil || !strings.HasPrefix(id, rootsRequestIDPrefix) {
+			return false
+		}
+		var result mcp.ListRootsResult
+		// Errors leave the previous roots in place, the client may not have any roots to offer
+		if message.Result != nil && json.Unmarshal(message.Result, &result) == nil {
+			c.onRoots(result.Roots)
+		}
+		return true
+	case string(mcp.MethodInitialize):
+		var params mcp.InitializeParams
+		if json.Unmarshal(message.Params, &params) == nil {
+			c.supported.Store(params.Capabilities.Roots != nil)
+		}
+	case "notifications/initialized", "notifications/roots/list_changed":
+		if c.supported.Load() {
+			// Sent asynchronously so that reading from the client never waits on writing to it
+			go c.requestRoots()
+		}
+	}
+	return false
+}
+
+// requestRoots sends a roots/list request to the client.
+func (c *rootsClient) requestRoots() {
+	request := struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      string `json:"id"`
+		Method  string `json:"method"`
+	}{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      fmt.Sprintf("%s%d", rootsRequestIDPrefix, c.nextID.Add(1)),
+		Method:  "roots/list",
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return
+	}
+	_, _ = c.out.Write(append(data, '\n'))
+}
+
+// lockedWriter serializes writes, so that requests to the client do not interleave with the
+// messages of the server.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
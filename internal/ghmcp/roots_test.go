@@ -0,0 +1,87 @@
+package ghmcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RootsClient(t *testing.T) {
+	t.Run("requests roots after initialization and consumes the response", func(t *testing.T) {
+		rootsC := make(chan []mcp.Root, 1)
+		client := newRootsClient(func(roots []mcp.Root) { rootsC <- roots })
+
+		clientIn, clientWriter := io.Pipe()
+		clientReader, serverOut := io.Pipe()
+		in, out := client.wrap(clientIn, serverOut)
+		serverLines := bufio.NewReader(in)
+		clientLines := bufio.NewReader(clientReader)
+
+		send := func(message string) {
+			go func() { _, _ = clientWriter.Write([]byte(message + "\n")) }()
+		}
+
+		send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{"roots":{"listChanged":true}},"clientInfo":{"name":"test","version":"1"}}}`)
+		line, err := serverLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, line, `"method":"initialize"`)
+
+		send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+		line, err = serverLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, line, "notifications/initialized")
+
+		// The server output is passed through and the roots request is written to the client
+		go func() { _, _ = out.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}` + "\n")) }()
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		for request.Method != "roots/list" {
+			line, err = clientLines.ReadString('\n')
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal([]byte(line), &request))
+		}
+		var requestID string
+		require.NoError(t, json.Unmarshal(request.ID, &requestID))
+		assert.True(t, strings.HasPrefix(requestID, rootsRequestIDPrefix))
+
+		send(`{"jsonrpc":"2.0","id":"` + requestID + `","result":{"roots":[{"uri":"file:///work/repo"}]}}`)
+		select {
+		case roots := <-rootsC:
+			assert.Equal(t, []mcp.Root{{URI: "file:///work/repo"}}, roots)
+		case <-time.After(time.Second):
+			t.Fatal("roots were not received")
+		}
+
+		// The response is not forwarded to the server
+		send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+		line, err = serverLines.ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, line, `"method":"ping"`)
+	})
+
+	t.Run("clients without roots are not asked", func(t *testing.T) {
+		client := newRootsClient(func([]mcp.Root) { t.Error("unexpected roots") })
+		client.out = &lockedWriter{w: writerFunc(func([]byte) { t.Error("unexpected request") })}
+
+		assert.False(t, client.intercept([]byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"capabilities":{}}}`)))
+		assert.False(t, client.intercept([]byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+		// Responses to other requests are forwarded
+		assert.False(t, client.intercept([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`)))
+	})
+}
+
+type writerFunc func([]byte)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	f(p)
+	return len(p), nil
+}
@@ -12,11 +12,13 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/github"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -53,18 +55,67 @@ type MCPServerConfig struct {
 
 	// AllowedRepos is a list of allowed repositories (e.g., ["repo1", "owner/repo2"])
 	AllowedRepos []string
+
+	// OutputFormat is the default format of read tool results (json, markdown, csv or table)
+	OutputFormat string
+
+	// MaxConcurrentRequests limits the number of concurrent requests per GitHub host, 0 means unlimited
+	MaxConcurrentRequests int
+
+	// IdempotencyStorePath is an optional file to persist idempotency keys to, in-memory only if empty
+	IdempotencyStorePath string
+
+	// HideUnusableTools checks the token at startup and hides the tools it cannot use
+	HideUnusableTools bool
+
+	// DynamicMaxActiveTools bounds the number of active tools in dynamic mode by disabling the
+	// least recently used toolsets, 0 means no limit
+	DynamicMaxActiveTools int
+
+	// Tools is an optional list of tool name patterns to allow, all tools of the enabled toolsets if empty
+	Tools []string
+
+	// ExcludeTools is an optional list of tool name patterns to remove
+	ExcludeTools []string
+
+	// RepositoryContext holds the default repository of sessions, created by the server if nil
+	RepositoryContext *github.RepositoryContext
 }
 
 const stdioServerLogPrefix = "stdioserver"
 
+// tokenAccessCheckTimeout bounds the startup requests that determine what the token can do.
+const tokenAccessCheckTimeout = 10 * time.Second
+
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
+	ghServer, _, err := newMCPServer(cfg)
+	return ghServer, err
+}
+
+// newMCPServer creates the MCP server and also returns the REST client it uses, for the parts of
+// the protocol that are handled outside of the server.
+func newMCPServer(cfg MCPServerConfig) (*server.MCPServer, github.GetClientFn, error) {
 	apiHost, err := parseAPIHost(cfg.Host)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse API host: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
+	outputFormat, err := github.ParseOutputFormat(cfg.OutputFormat)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse output format: %w", err)
+	}
+
+	idempotencyStore, err := github.NewIdempotencyStore(cfg.IdempotencyStorePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create idempotency store: %w", err)
+	}
+
+	// All clients share one transport, so that identical reads are coalesced and the
+	// concurrency limit applies across REST and GraphQL requests
+	transport := newOutboundTransport(http.DefaultTransport, cfg.MaxConcurrentRequests)
+
 	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	restClient := gogithub.NewClient(&http.Client{Transport: transport}).WithAuthToken(cfg.Token)
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
@@ -74,7 +125,7 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// did the necessary API host parsing so that github.com will return the correct URL anyway.
 	gqlHTTPClient := &http.Client{
 		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
+			transport: transport,
 			token:     cfg.Token,
 		},
 	} // We're going to wrap the Transport later in beforeInit
@@ -97,8 +148,12 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
+	// Tool calls can be cancelled by the client and report progress to it
+	calls := github.NewCallTracker()
+
 	hooks := &server.Hooks{
 		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
+		OnBeforeCallTool:   []server.OnBeforeCallToolFunc{calls.BeforeCallTool},
 		OnBeforeAny: []server.BeforeAnyHookFunc{
 			func(ctx context.Context, _ any, _ mcp.MCPMethod, _ any) {
 				// Ensure the context is cleared of any previous errors
@@ -108,7 +163,8 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		},
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks), server.WithToolHandlerMiddleware(calls.Middleware))
+	ghServer.AddNotificationHandler("notifications/cancelled", calls.HandleCancelled)
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -140,23 +196,46 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// Create repository permission checker
 	repoChecker := github.NewRepoPermissionChecker(cfg.AllowedRepos, getClient)
 
+	var tokenAccess *github.TokenAccess
+	if cfg.HideUnusableTools {
+		ctx, cancel := context.WithTimeout(context.Background(), tokenAccessCheckTimeout)
+		// Hiding was explicitly requested, so a token that cannot be inspected stops the startup
+		// rather than silently offering every tool
+		var err error
+		tokenAccess, err = github.CheckTokenAccess(ctx, restClient)
+		cancel()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check token access: %w", err)
+		}
+	}
+
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize, repoChecker)
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize, repoChecker, outputFormat, idempotencyStore, tokenAccess, cfg.RepositoryContext)
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
+		return nil, nil, fmt.Errorf("failed to enable toolsets: %w", err)
+	}
+
+	// Filter individual tools, this applies to both static and dynamic registration
+	if err := tsg.FilterTools(cfg.Tools, cfg.ExcludeTools); err != nil {
+		return nil, nil, fmt.Errorf("failed to filter tools: %w", err)
+	}
+
+	// The dynamic toolset must be created before registration, so that the use of the registered tools is tracked
+	var dynamic *toolsets.Toolset
+	if cfg.DynamicToolsets {
+		dynamic = github.InitDynamicToolset(ghServer, tsg, cfg.Translator, cfg.DynamicMaxActiveTools)
 	}
 
 	// Register all mcp functionality with the server
 	tsg.RegisterAll(ghServer)
 
-	if cfg.DynamicToolsets {
-		dynamic := github.InitDynamicToolset(ghServer, tsg, cfg.Translator)
+	if dynamic != nil {
 		dynamic.RegisterTools(ghServer)
 	}
 
-	return ghServer, nil
+	return ghServer, getClient, nil
 }
 
 type StdioServerConfig struct {
@@ -195,6 +274,28 @@ type StdioServerConfig struct {
 
 	// AllowedRepos is a list of allowed repositories (e.g., ["repo1", "owner/repo2"])
 	AllowedRepos []string
+
+	// OutputFormat is the default format of read tool results (json, markdown, csv or table)
+	OutputFormat string
+
+	// MaxConcurrentRequests limits the number of concurrent requests per GitHub host, 0 means unlimited
+	MaxConcurrentRequests int
+
+	// IdempotencyStorePath is an optional file to persist idempotency keys to, in-memory only if empty
+	IdempotencyStorePath string
+
+	// HideUnusableTools checks the token at startup and hides the tools it cannot use
+	HideUnusableTools bool
+
+	// DynamicMaxActiveTools bounds the number of active tools in dynamic mode by disabling the
+	// least recently used toolsets, 0 means no limit
+	DynamicMaxActiveTools int
+
+	// Tools is an optional list of tool name patterns to allow, all tools of the enabled toolsets if empty
+	Tools []string
+
+	// ExcludeTools is an optional list of tool name patterns to remove
+	ExcludeTools []string
 }
 
 // RunStdioServer is not concurrent safe.
@@ -205,22 +306,43 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
-		AllowedRepos:      cfg.AllowedRepos,
+	apiHost, err := parseAPIHost(cfg.Host)
+	if err != nil {
+		return fmt.Errorf("failed to parse API host: %w", err)
+	}
+	// The repositories of the local checkouts in the roots of the client are the default for tools
+	repoContext := github.NewRepositoryContext()
+	roots := newRootsClient(func(roots []mcp.Root) {
+		repoContext.SetWorkspaceRepositories(github.DetectWorkspaceRepositories(apiHost.gitHost, roots))
+	})
+
+	ghServer, getClient, err := newMCPServer(MCPServerConfig{
+		Version:               cfg.Version,
+		Host:                  cfg.Host,
+		Token:                 cfg.Token,
+		EnabledToolsets:       cfg.EnabledToolsets,
+		DynamicToolsets:       cfg.DynamicToolsets,
+		ReadOnly:              cfg.ReadOnly,
+		Translator:            t,
+		ContentWindowSize:     cfg.ContentWindowSize,
+		AllowedRepos:          cfg.AllowedRepos,
+		OutputFormat:          cfg.OutputFormat,
+		MaxConcurrentRequests: cfg.MaxConcurrentRequests,
+		IdempotencyStorePath:  cfg.IdempotencyStorePath,
+		HideUnusableTools:     cfg.HideUnusableTools,
+		DynamicMaxActiveTools: cfg.DynamicMaxActiveTools,
+		Tools:                 cfg.Tools,
+		ExcludeTools:          cfg.ExcludeTools,
+		RepositoryContext:     repoContext,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
 
+	completions := newCompletionHandler(github.NewCompleter(getClient, github.NewRepoPermissionChecker(cfg.AllowedRepos, getClient), repoContext))
+
 	stdioServer := server.NewStdioServer(ghServer)
+	stdioServer.SetContextFunc(completions.serverContext)
 
 	var slogHandler slog.Handler
 	var logOutput io.Writer
@@ -254,6 +376,8 @@ func RunStdioServer(cfg StdioServerConfig) error {
 			loggedIO := mcplog.NewIOLogger(in, out, logger)
 			in, out = loggedIO, loggedIO
 		}
+		in, out = roots.wrap(in, out)
+		in, out = completions.wrap(in, out)
 		// enable GitHub errors in the context
 		ctx := errors.ContextWithGitHubErrors(ctx)
 		errC <- stdioServer.Listen(ctx, in, out)
@@ -281,6 +405,8 @@ type apiHost struct {
 	graphqlURL  *url.URL
 	uploadURL   *url.URL
 	rawURL      *url.URL
+	// gitHost is the hostname of git remotes, e.g. github.com
+	gitHost string
 }
 
 func newDotcomHost() (apiHost, error) {
@@ -309,6 +435,7 @@ func newDotcomHost() (apiHost, error) {
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		gitHost:     "github.com",
 	}, nil
 }
 
@@ -348,6 +475,7 @@ func newGHECHost(hostname string) (apiHost, error) {
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		gitHost:     u.Hostname(),
 	}, nil
 }
 
@@ -381,6 +509,7 @@ func newGHESHost(hostname string) (apiHost, error) {
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		gitHost:     u.Hostname(),
 	}, nil
 }
 
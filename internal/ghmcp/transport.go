@@ -0,0 +1,171 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// coalescingTransport shares a single upstream round trip between identical GET requests that
+// are in flight at the same time. Agents frequently issue parallel tool calls that resolve the
+// same user, repository or ref, and each of them would otherwise hit the API separately.
+type coalescingTransport struct {
+	transport http.RoundTripper
+
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+type inflightCall struct {
+	done chan struct{}
+	resp *http.Response
+	body []byte
+	err  error
+}
+
+func newCoalescingTransport(transport http.RoundTripper) *coalescingTransport {
+	return &coalescingTransport{
+		transport: transport,
+		calls:     make(map[string]*inflightCall),
+	}
+}
+
+func (t *coalescingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if (req.Method != http.MethodGet && req.Method != http.MethodHead) || req.Body != nil && req.Body != http.NoBody {
+		return t.transport.RoundTrip(req)
+	}
+
+	key := coalescingKey(req)
+
+	t.mu.Lock()
+	if c, ok := t.calls[key]; ok {
+		t.mu.Unlock()
+		select {
+		case <-c.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if c.err != nil && isContextError(c.err) && req.Context().Err() == nil {
+			// The request we waited on was cancelled by its caller, this one is still wanted
+			return t.transport.RoundTrip(req)
+		}
+		return c.response(req)
+	}
+	c := &inflightCall{done: make(chan struct{})}
+	t.calls[key] = c
+	t.mu.Unlock()
+
+	c.resp, c.err = t.transport.RoundTrip(req)
+	if c.err == nil {
+		c.body, c.err = io.ReadAll(c.resp.Body)
+		_ = c.resp.Body.Close()
+	}
+
+	t.mu.Lock()
+	delete(t.calls, key)
+	t.mu.Unlock()
+	close(c.done)
+
+	return c.response(req)
+}
+
+// response returns a copy of the shared response with its own body, so every caller can read and close it.
+func (c *inflightCall) response(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	resp := *c.resp
+	resp.Header = c.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(c.body))
+	resp.ContentLength = int64(len(c.body))
+	resp.Request = req
+	return &resp, nil
+}
+
+// coalescingKey identifies requests that are guaranteed to get the same response.
+func coalescingKey(req *http.Request) string {
+	return strings.Join([]string{
+		req.Method,
+		req.URL.String(),
+		req.Header.Get("Authorization"),
+		req.Header.Get("Accept"),
+		req.Header.Get("Range"),
+	}, "\n")
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// hostLimitTransport caps the number of concurrent requests per host. Requests over the limit
+// queue until a slot frees up or their context is done. Bursts of parallel requests are a
+// common trigger of GitHub's secondary rate limits.
+type hostLimitTransport struct {
+	transport http.RoundTripper
+	limit     int
+
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func newHostLimitTransport(transport http.RoundTripper, limit int) *hostLimitTransport {
+	return &hostLimitTransport{
+		transport: transport,
+		limit:     limit,
+		slots:     make(map[string]chan struct{}),
+	}
+}
+
+func (t *hostLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	slots := t.hostSlots(req.URL.Host)
+	select {
+	case slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		<-slots
+		return nil, err
+	}
+	// The slot is held until the body has been consumed, as the connection is in use until then
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: func() { <-slots }}
+	return resp, nil
+}
+
+func (t *hostLimitTransport) hostSlots(host string) chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	slots, ok := t.slots[host]
+	if !ok {
+		slots = make(chan struct{}, t.limit)
+		t.slots[host] = slots
+	}
+	return slots
+}
+
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// newOutboundTransport returns the transport shared by all GitHub API clients. Identical reads
+// are coalesced and, when maxConcurrentRequests is positive, requests are limited per host.
+func newOutboundTransport(base http.RoundTripper, maxConcurrentRequests int) http.RoundTripper {
+	if maxConcurrentRequests > 0 {
+		base = newHostLimitTransport(base, maxConcurrentRequests)
+	}
+	return newCoalescingTransport(base)
+}
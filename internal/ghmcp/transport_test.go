@@ -0,0 +1,150 @@
+package ghmcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func okResponse(req *http.Request, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func Test_CoalescingTransport(t *testing.T) {
+	t.Run("identical in-flight GETs share one round trip", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		transport := newCoalescingTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls.Add(1)
+			<-release
+			return okResponse(req, `{"login":"octocat"}`), nil
+		}))
+
+		const callers = 5
+		var wg sync.WaitGroup
+		bodies := make([]string, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+				resp, err := transport.RoundTrip(req)
+				require.NoError(t, err)
+				defer func() { _ = resp.Body.Close() }()
+				body, _ := io.ReadAll(resp.Body)
+				bodies[i] = string(body)
+			}(i)
+		}
+
+		// Wait for all callers to join the in-flight request before letting it complete
+		require.Eventually(t, func() bool {
+			transport.mu.Lock()
+			defer transport.mu.Unlock()
+			return len(transport.calls) == 1
+		}, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+		for _, body := range bodies {
+			assert.Equal(t, `{"login":"octocat"}`, body)
+		}
+	})
+
+	t.Run("requests that differ or write are not coalesced", func(t *testing.T) {
+		var calls atomic.Int32
+		transport := newCoalescingTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls.Add(1)
+			return okResponse(req, `{}`), nil
+		}))
+
+		requests := []*http.Request{}
+		get, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r", nil)
+		requests = append(requests, get)
+		raw, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r", nil)
+		raw.Header.Set("Accept", "application/vnd.github.raw")
+		requests = append(requests, raw)
+		post, _ := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", strings.NewReader(`{}`))
+		requests = append(requests, post)
+
+		for _, req := range requests {
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+		}
+		assert.Equal(t, int32(3), calls.Load())
+	})
+}
+
+func Test_HostLimitTransport(t *testing.T) {
+	var active, maxActive atomic.Int32
+	transport := newHostLimitTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		n := active.Add(1)
+		for {
+			current := maxActive.Load()
+			if n <= current || maxActive.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		active.Add(-1)
+		return okResponse(req, `{}`), nil
+	}), 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, maxActive.Load(), int32(2))
+
+	t.Run("queued requests give up when their context is done", func(t *testing.T) {
+		blocked := newHostLimitTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return okResponse(req, `{}`), nil
+		}), 1)
+
+		first, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		resp, err := blocked.RoundTrip(first)
+		require.NoError(t, err)
+		// The slot is held until the body is closed
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		second, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+		_, err = blocked.RoundTrip(second)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		_ = resp.Body.Close()
+		third, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		resp, err = blocked.RoundTrip(third)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	})
+}
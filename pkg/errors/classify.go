@@ -0,0 +1,230 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ErrorCategory is a stable, machine-readable classification of a failed GitHub request.
+type ErrorCategory string
+
+const (
+	CategoryNotFound            ErrorCategory = "not_found"
+	CategoryPermissionDenied    ErrorCategory = "permission_denied"
+	CategoryRateLimited         ErrorCategory = "rate_limited"
+	CategoryValidationFailed    ErrorCategory = "validation_failed"
+	CategoryConflict            ErrorCategory = "conflict"
+	CategorySSORequired         ErrorCategory = "sso_required"
+	CategoryUpstreamUnavailable ErrorCategory = "upstream_unavailable"
+	CategoryUnknown             ErrorCategory = "unknown"
+)
+
+// ErrorMetaKey is the key of the ErrorInfo in the _meta of error tool results.
+const ErrorMetaKey = "github/error"
+
+// ErrorInfo describes why a GitHub request failed, so that clients can decide between retrying,
+// re-authenticating and giving up without parsing error messages.
+type ErrorInfo struct {
+	Category          ErrorCategory `json:"category"`
+	Retryable         bool          `json:"retryable"`
+	RetryAfterSeconds int           `json:"retryAfterSeconds,omitempty"`
+	StatusCode        int           `json:"statusCode,omitempty"`
+	RequestID         string        `json:"requestId,omitempty"`
+}
+
+// WithErrorInfo attaches the error classification to the _meta of a tool result.
+func WithErrorInfo(result *mcp.CallToolResult, info ErrorInfo) *mcp.CallToolResult {
+	if result.Meta == nil {
+		result.Meta = make(map[string]any)
+	}
+	result.Meta[ErrorMetaKey] = info
+	return result
+}
+
+// ClassifyAPIError classifies a failed REST request. The response may be nil, in which case it
+// is taken from the error when possible.
+func ClassifyAPIError(resp *github.Response, err error) ErrorInfo {
+	var httpResp *http.Response
+	if resp != nil && resp.Response != nil {
+		httpResp = resp.Response
+	} else {
+		httpResp = responseFromError(err)
+	}
+
+	info := ErrorInfo{Category: CategoryUnknown}
+	if httpResp != nil {
+		info.StatusCode = httpResp.StatusCode
+		info.RequestID = httpResp.Header.Get("X-GitHub-Request-Id")
+	}
+
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	switch {
+	case errors.As(err, &rateLimitErr):
+		info.Category = CategoryRateLimited
+		info.RetryAfterSeconds = secondsUntil(rateLimitErr.Rate.Reset.Time)
+	case errors.As(err, &abuseErr):
+		info.Category = CategoryRateLimited
+		if abuseErr.RetryAfter != nil {
+			info.RetryAfterSeconds = int(abuseErr.RetryAfter.Seconds())
+		}
+	case httpResp != nil:
+		info.Category = categoryFromResponse(httpResp, err)
+	case isUnavailableError(err):
+		info.Category = CategoryUpstreamUnavailable
+	}
+
+	if info.Category == CategoryRateLimited && info.RetryAfterSeconds == 0 && httpResp != nil {
+		info.RetryAfterSeconds = retryAfterFromHeaders(httpResp.Header)
+	}
+	info.Retryable = info.Category == CategoryRateLimited || info.Category == CategoryUpstreamUnavailable
+	return info
+}
+
+var graphQLStatusPattern = regexp.MustCompile(`non-200 ok status code: (\d{3})`)
+
+// ClassifyGraphQLError classifies a failed GraphQL request. GraphQL errors are only exposed as
+// messages by the client, so the classification is based on the well known messages of the API.
+func ClassifyGraphQLError(err error) ErrorInfo {
+	info := ErrorInfo{Category: CategoryUnknown}
+	if err == nil {
+		return info
+	}
+	message := strings.ToLower(err.Error())
+
+	if match := graphQLStatusPattern.FindStringSubmatch(message); match != nil {
+		info.StatusCode, _ = strconv.Atoi(match[1])
+	}
+
+	switch {
+	case strings.Contains(message, "saml"):
+		info.Category = CategorySSORequired
+	case strings.Contains(message, "rate limit"):
+		info.Category = CategoryRateLimited
+	case strings.Contains(message, "could not resolve") || strings.Contains(message, "not found"):
+		info.Category = CategoryNotFound
+	case strings.Contains(message, "resource not accessible") || strings.Contains(message, "forbidden") ||
+		strings.Contains(message, "bad credentials") || strings.Contains(message, "must have"):
+		info.Category = CategoryPermissionDenied
+	case info.StatusCode >= http.StatusInternalServerError || isUnavailableError(err):
+		info.Category = CategoryUpstreamUnavailable
+	case info.StatusCode != 0:
+		info.Category = categoryFromStatus(info.StatusCode)
+	}
+
+	info.Retryable = info.Category == CategoryRateLimited || info.Category == CategoryUpstreamUnavailable
+	return info
+}
+
+// ClassifyError classifies an error returned by a tool handler. It reports false when the error
+// does not originate from a request to GitHub, e.g. a failure to build a client.
+func ClassifyError(err error) (ErrorInfo, bool) {
+	var apiErr *GitHubAPIError
+	if errors.As(err, &apiErr) {
+		return ClassifyAPIError(apiErr.Response, apiErr.Err), true
+	}
+	var graphQLErr *GitHubGraphQLError
+	if errors.As(err, &graphQLErr) {
+		return ClassifyGraphQLError(graphQLErr.Err), true
+	}
+	if responseFromError(err) != nil || isUnavailableError(err) {
+		return ClassifyAPIError(nil, err), true
+	}
+	return ErrorInfo{}, false
+}
+
+func responseFromError(err error) *http.Response {
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response
+	}
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr.Response
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return abuseErr.Response
+	}
+	return nil
+}
+
+func categoryFromResponse(resp *http.Response, err error) ErrorCategory {
+	if resp.StatusCode == http.StatusForbidden {
+		if resp.Header.Get("X-GitHub-SSO") != "" {
+			return CategorySSORequired
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != "" ||
+			(err != nil && strings.Contains(strings.ToLower(err.Error()), "rate limit")) {
+			return CategoryRateLimited
+		}
+	}
+	return categoryFromStatus(resp.StatusCode)
+}
+
+func categoryFromStatus(status int) ErrorCategory {
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return CategoryNotFound
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return CategoryPermissionDenied
+	case status == http.StatusTooManyRequests:
+		return CategoryRateLimited
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return CategoryValidationFailed
+	case status == http.StatusConflict || status == http.StatusPreconditionFailed:
+		return CategoryConflict
+	case status >= http.StatusInternalServerError:
+		return CategoryUpstreamUnavailable
+	default:
+		return CategoryUnknown
+	}
+}
+
+// retryAfterFromHeaders returns the number of seconds to wait before retrying, based on the
+// Retry-After header or, for exhausted primary rate limits, the X-RateLimit-Reset header.
+func retryAfterFromHeaders(header http.Header) int {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return seconds
+		}
+	}
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return secondsUntil(time.Unix(reset, 0))
+		}
+	}
+	return 0
+}
+
+func secondsUntil(t time.Time) int {
+	seconds := int(time.Until(t).Round(time.Second).Seconds())
+	if seconds < 0 {
+		return 0
+	}
+	return seconds
+}
+
+// isUnavailableError reports whether the request failed without reaching GitHub, e.g. due to
+// network errors or timeouts. Cancellation by the caller is not considered an upstream failure.
+func isUnavailableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
+}
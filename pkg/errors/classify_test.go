@@ -0,0 +1,152 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func errorResponse(status int, header http.Header) *github.ErrorResponse {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("X-GitHub-Request-Id", "ABCD:1234")
+	return &github.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Header: header},
+		Message:  http.StatusText(status),
+	}
+}
+
+func TestClassifyAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorInfo
+	}{
+		{
+			name:     "not found",
+			err:      errorResponse(http.StatusNotFound, nil),
+			expected: ErrorInfo{Category: CategoryNotFound, StatusCode: 404, RequestID: "ABCD:1234"},
+		},
+		{
+			name:     "permission denied",
+			err:      errorResponse(http.StatusForbidden, nil),
+			expected: ErrorInfo{Category: CategoryPermissionDenied, StatusCode: 403, RequestID: "ABCD:1234"},
+		},
+		{
+			name:     "sso required",
+			err:      errorResponse(http.StatusForbidden, http.Header{"X-Github-Sso": []string{"required; url=https://github.com/orgs/o/sso"}}),
+			expected: ErrorInfo{Category: CategorySSORequired, StatusCode: 403, RequestID: "ABCD:1234"},
+		},
+		{
+			name:     "secondary rate limit with retry after",
+			err:      errorResponse(http.StatusForbidden, http.Header{"Retry-After": []string{"60"}}),
+			expected: ErrorInfo{Category: CategoryRateLimited, Retryable: true, RetryAfterSeconds: 60, StatusCode: 403, RequestID: "ABCD:1234"},
+		},
+		{
+			name:     "validation failed",
+			err:      errorResponse(http.StatusUnprocessableEntity, nil),
+			expected: ErrorInfo{Category: CategoryValidationFailed, StatusCode: 422, RequestID: "ABCD:1234"},
+		},
+		{
+			name:     "conflict",
+			err:      errorResponse(http.StatusConflict, nil),
+			expected: ErrorInfo{Category: CategoryConflict, StatusCode: 409, RequestID: "ABCD:1234"},
+		},
+		{
+			name:     "upstream unavailable",
+			err:      fmt.Errorf("failed to get issue: %w", errorResponse(http.StatusBadGateway, nil)),
+			expected: ErrorInfo{Category: CategoryUpstreamUnavailable, Retryable: true, StatusCode: 502, RequestID: "ABCD:1234"},
+		},
+		{
+			name:     "network error",
+			err:      &url.Error{Op: "Get", URL: "https://api.github.com/user", Err: fmt.Errorf("connection refused")},
+			expected: ErrorInfo{Category: CategoryUpstreamUnavailable, Retryable: true},
+		},
+		{
+			name: "abuse rate limit",
+			err: &github.AbuseRateLimitError{
+				Response:   &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}},
+				RetryAfter: github.Ptr(30 * time.Second),
+			},
+			expected: ErrorInfo{Category: CategoryRateLimited, Retryable: true, RetryAfterSeconds: 30, StatusCode: 403},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ClassifyAPIError(nil, tc.err))
+		})
+	}
+
+	t.Run("primary rate limit uses the reset time", func(t *testing.T) {
+		reset := time.Now().Add(2 * time.Minute)
+		err := &github.RateLimitError{
+			Rate:     github.Rate{Reset: github.Timestamp{Time: reset}},
+			Response: &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(reset.Unix(), 10)}}},
+		}
+		info := ClassifyAPIError(nil, err)
+		assert.Equal(t, CategoryRateLimited, info.Category)
+		assert.True(t, info.Retryable)
+		assert.InDelta(t, 120, info.RetryAfterSeconds, 2)
+	})
+
+	t.Run("cancelled requests are not classified as upstream failures", func(t *testing.T) {
+		err := &url.Error{Op: "Get", URL: "https://api.github.com/user", Err: context.Canceled}
+		assert.Equal(t, CategoryUnknown, ClassifyAPIError(nil, err).Category)
+	})
+}
+
+func TestClassifyGraphQLError(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected ErrorCategory
+	}{
+		{"Could not resolve to a Repository with the name 'owner/repo'.", CategoryNotFound},
+		{"Resource not accessible by personal access token", CategoryPermissionDenied},
+		{"API rate limit exceeded for user ID 1.", CategoryRateLimited},
+		{"Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization.", CategorySSORequired},
+		{"non-200 OK status code: 502 Bad Gateway body: \"\"", CategoryUpstreamUnavailable},
+		{"something unexpected", CategoryUnknown},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.message, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ClassifyGraphQLError(fmt.Errorf("%s", tc.message)).Category)
+		})
+	}
+}
+
+func TestErrorResponsesCarryErrorInfo(t *testing.T) {
+	ctx := ContextWithGitHubErrors(context.Background())
+	err := errorResponse(http.StatusNotFound, nil)
+
+	result := NewGitHubAPIErrorResponse(ctx, "failed to get issue", &github.Response{Response: err.Response}, err)
+	require.True(t, result.IsError)
+	info, ok := result.Meta[ErrorMetaKey].(ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, CategoryNotFound, info.Category)
+	assert.Equal(t, "ABCD:1234", info.RequestID)
+
+	result = NewGitHubGraphQLErrorResponse(ctx, "failed to list discussions", fmt.Errorf("Could not resolve to a Repository"))
+	info, ok = result.Meta[ErrorMetaKey].(ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, CategoryNotFound, info.Category)
+
+	resp := &github.Response{Response: &http.Response{StatusCode: http.StatusAccepted, Header: http.Header{}}}
+	result = NewGitHubAPIStatusErrorResponse(ctx, "failed to create issue", resp, []byte("still processing"))
+	require.True(t, result.IsError)
+	assert.Equal(t, "failed to create issue: still processing", result.Content[0].(mcp.TextContent).Text)
+	info, ok = result.Meta[ErrorMetaKey].(ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusAccepted, info.StatusCode)
+}
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/go-github/v74/github"
@@ -106,20 +107,25 @@ func addGitHubGraphQLErrorToContext(ctx context.Context, err *GitHubGraphQLError
 	return nil, fmt.Errorf("context does not contain GitHubCtxErrors")
 }
 
-// NewGitHubAPIErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
+// NewGitHubAPIErrorResponse returns an mcp.NewToolResultError classified with an ErrorInfo and retains the error in the context for access via middleware
 func NewGitHubAPIErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
 	apiErr := newGitHubAPIError(message, resp, err)
 	if ctx != nil {
 		_, _ = addGitHubAPIErrorToContext(ctx, apiErr) // Explicitly ignore error for graceful handling
 	}
-	return mcp.NewToolResultErrorFromErr(message, err)
+	return WithErrorInfo(mcp.NewToolResultErrorFromErr(message, err), ClassifyAPIError(resp, err))
 }
 
-// NewGitHubGraphQLErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
+// NewGitHubAPIStatusErrorResponse returns a classified error result for a response with an unexpected status code, using the response body as the error
+func NewGitHubAPIStatusErrorResponse(ctx context.Context, message string, resp *github.Response, body []byte) *mcp.CallToolResult {
+	return NewGitHubAPIErrorResponse(ctx, message, resp, errors.New(string(body)))
+}
+
+// NewGitHubGraphQLErrorResponse returns an mcp.NewToolResultError classified with an ErrorInfo and retains the error in the context for access via middleware
 func NewGitHubGraphQLErrorResponse(ctx context.Context, message string, err error) *mcp.CallToolResult {
 	graphQLErr := newGitHubGraphQLError(message, err)
 	if ctx != nil {
 		_, _ = addGitHubGraphQLErrorToContext(ctx, graphQLErr) // Explicitly ignore error for graceful handling
 	}
-	return mcp.NewToolResultErrorFromErr(message, err)
+	return WithErrorInfo(mcp.NewToolResultErrorFromErr(message, err), ClassifyGraphQLError(err))
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/github/github-mcp-server/internal/profiler"
 	buffer "github.com/github/github-mcp-server/pkg/buffer"
@@ -150,6 +151,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("queued", "in_progress", "completed", "requested", "waiting"),
 			),
 			WithPagination(),
+			WithFetchAll(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -188,6 +190,11 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			fetchAll, err := OptionalFetchAllParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination.PerPage = fetchAll.pageSize(request, pagination.PerPage)
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -206,9 +213,40 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				},
 			}
 
+			if fetchAll.FetchAll {
+				var totalCount int
+				var countOnce sync.Once
+				runs, resp, err := fetchRESTPages(ctx, pagination.Page, pagination.PerPage, fetchAll.MaxItems,
+					func(ctx context.Context, page int) ([]*github.WorkflowRun, *github.Response, error) {
+						pageOpts := *opts
+						pageOpts.Page = page
+						workflowRuns, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, &pageOpts)
+						if err != nil {
+							return nil, resp, err
+						}
+						// The total is taken from the first page fetched, which may differ from the
+						// requested page as fetchRESTPages starts at page 1 at the earliest
+						countOnce.Do(func() { totalCount = workflowRuns.GetTotalCount() })
+						return workflowRuns.WorkflowRuns, resp, nil
+					})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs", resp, err), nil
+				}
+
+				r, err := json.Marshal(&github.WorkflowRuns{
+					TotalCount:   github.Ptr(totalCount),
+					WorkflowRuns: runs,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
 			workflowRuns, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, opts)
 			if err != nil {
-				return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs", resp, err), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -656,7 +694,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 
 	// Collect logs for all failed jobs
 	var logResults []map[string]any
-	for _, job := range failedJobs {
+	for i, job := range failedJobs {
 		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines, contentWindowSize)
 		if err != nil {
 			// Continue with other jobs even if one fails
@@ -670,6 +708,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		}
 
 		logResults = append(logResults, jobResult)
+		ReportProgress(ctx, float64(i+1), float64(len(failedJobs)), fmt.Sprintf("Retrieved logs for job %s", job.GetName()))
 	}
 
 	result := map[string]any{
@@ -747,7 +786,12 @@ func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLi
 	prof := profiler.New(nil, profiler.IsProfilingEnabled())
 	finish := prof.Start(ctx, "log_buffer_processing")
 
-	httpResp, err := http.Get(logURL) //nolint:gosec
+	// The request is bound to ctx, so that cancelling the tool call aborts the download
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL, nil)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("failed to create log download request: %w", err)
+	}
+	httpResp, err := http.DefaultClient.Do(req) //nolint:gosec
 	if err != nil {
 		return "", 0, httpResp, fmt.Errorf("failed to download logs: %w", err)
 	}
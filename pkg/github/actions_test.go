@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -132,6 +133,63 @@ func Test_ListWorkflows(t *testing.T) {
 	}
 }
 
+func Test_ListWorkflowRuns(t *testing.T) {
+	for _, fetchAll := range []bool{false, true} {
+		t.Run(fmt.Sprintf("failure with fetch_all %t", fetchAll), func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			))
+			_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+				"fetch_all":   fetchAll,
+			}))
+
+			// Both paths report the failure as a tool result rather than a protocol error
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, getErrorResult(t, result).Text, "failed to list workflow runs")
+		})
+	}
+}
+
+func Test_ListWorkflowRuns_FetchAllTotalCount(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+			expectQueryParams(t, map[string]string{"page": "1", "per_page": "100"}).andThen(
+				mockResponse(t, http.StatusOK, &github.WorkflowRuns{
+					TotalCount:   github.Ptr(2),
+					WorkflowRuns: []*github.WorkflowRun{{ID: github.Ptr(int64(1))}, {ID: github.Ptr(int64(2))}},
+				}),
+			),
+		),
+	))
+	_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	// A page below 1 starts at page 1, whose total is reported
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"workflow_id": "ci.yml",
+		"fetch_all":   true,
+		"page":        float64(-1),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var runs github.WorkflowRuns
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &runs))
+	assert.Equal(t, 2, runs.GetTotalCount())
+	assert.Len(t, runs.WorkflowRuns, 2)
+}
+
 func Test_RunWorkflow(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// MaxBatchCalls is the maximum number of tool calls accepted by batch_read.
+	MaxBatchCalls = 20
+	// maxConcurrentBatchCalls limits the number of batched tool calls executed in parallel.
+	maxConcurrentBatchCalls = 4
+)
+
+// batchCall is a single tool invocation of a batch_read request.
+type batchCall struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// batchCallResult is the outcome of a single batched tool call.
+type batchCallResult struct {
+	Tool    string        `json:"tool"`
+	IsError bool          `json:"isError"`
+	Content []mcp.Content `json:"content,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// BatchRead creates a tool that executes several read-only tool calls concurrently in one request.
+func BatchRead(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("batch_read",
+			mcp.WithDescription(t("TOOL_BATCH_READ_DESCRIPTION", fmt.Sprintf("Execute up to %d read-only tool calls in a single request. Calls run concurrently and their results or errors are returned in the same order as the calls. Use this to gather context, e.g. a pull request together with its files, reviews and comments, instead of calling the tools one by one.", MaxBatchCalls))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BATCH_READ_USER_TITLE", "Batch read"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithArray("calls",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"tool"},
+						"properties": map[string]interface{}{
+							"tool": map[string]interface{}{
+								"type":        "string",
+								"description": "name of an enabled read-only tool",
+							},
+							"arguments": map[string]interface{}{
+								"type":        "object",
+								"description": "arguments of the tool call",
+							},
+						},
+					}),
+				mcp.Description("Array of tool calls, each object with tool (string) and arguments (object)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls, err := parseBatchCalls(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Validate every call up front so that a batch is either run completely or not at all
+			activeTools := make(map[string]server.ServerTool)
+			for _, st := range toolsetGroup.ActiveTools() {
+				activeTools[st.Tool.Name] = st
+			}
+			handlers := make([]server.ToolHandlerFunc, len(calls))
+			for i, call := range calls {
+				st, ok := activeTools[call.Tool]
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("call %d: tool %s is not enabled", i, call.Tool)), nil
+				}
+				if st.Tool.Annotations.ReadOnlyHint == nil || !*st.Tool.Annotations.ReadOnlyHint {
+					return mcp.NewToolResultError(fmt.Sprintf("call %d: tool %s is not read-only", i, call.Tool)), nil
+				}
+				if call.Tool == "batch_read" {
+					return mcp.NewToolResultError(fmt.Sprintf("call %d: batch_read cannot be nested", i)), nil
+				}
+				handlers[i] = st.Handler
+			}
+
+			results := make([]batchCallResult, len(calls))
+			sem := make(chan struct{}, maxConcurrentBatchCalls)
+			var wg sync.WaitGroup
+			for i, call := range calls {
+				wg.Add(1)
+				go func(i int, call batchCall) {
+					defer wg.Done()
+					select {
+					case sem <- struct{}{}:
+						defer func() { <-sem }()
+					case <-ctx.Done():
+						// The request was cancelled before the call could start
+						results[i] = batchCallResult{Tool: call.Tool, IsError: true, Error: ctx.Err().Error()}
+						return
+					}
+
+					results[i] = runBatchCall(ctx, call, handlers[i])
+				}(i, call)
+			}
+			wg.Wait()
+
+			return MarshalledTextResult(results), nil
+		}
+}
+
+func runBatchCall(ctx context.Context, call batchCall, handler server.ToolHandlerFunc) batchCallResult {
+	result := batchCallResult{Tool: call.Tool}
+	if err := ctx.Err(); err != nil {
+		result.IsError = true
+		result.Error = err.Error()
+		return result
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = call.Tool
+	request.Params.Arguments = call.Arguments
+	if request.Params.Arguments == nil {
+		request.Params.Arguments = map[string]any{}
+	}
+
+	toolResult, err := handler(ctx, request)
+	if err != nil {
+		result.IsError = true
+		result.Error = err.Error()
+		return result
+	}
+	result.IsError = toolResult.IsError
+	result.Content = toolResult.Content
+	return result
+}
+
+func parseBatchCalls(r mcp.CallToolRequest) ([]batchCall, error) {
+	rawCalls, ok := r.GetArguments()["calls"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: calls")
+	}
+	if len(rawCalls) == 0 {
+		return nil, fmt.Errorf("calls must not be empty")
+	}
+	if len(rawCalls) > MaxBatchCalls {
+		return nil, fmt.Errorf("at most %d calls can be batched, got %d", MaxBatchCalls, len(rawCalls))
+	}
+
+	calls := make([]batchCall, 0, len(rawCalls))
+	for i, rawCall := range rawCalls {
+		callMap, ok := rawCall.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("call %d must be an object", i)
+		}
+		name, ok := callMap["tool"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("call %d: tool must be a non-empty string", i)
+		}
+		call := batchCall{Tool: name}
+		if args, exists := callMap["arguments"]; exists && args != nil {
+			call.Arguments, ok = args.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("call %d: arguments must be an object", i)
+			}
+		}
+		calls = append(calls, call)
+	}
+	return calls, nil
+}
@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BatchRead(t *testing.T) {
+	tsg := toolsets.NewToolsetGroup(false)
+	tool, _ := BatchRead(tsg, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "batch_read", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "batch_read tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "calls")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"calls"})
+
+	echo := server.ServerTool{
+		Tool: mcp.NewTool("echo", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			value, err := RequiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultText(value), nil
+		},
+	}
+	failing := server.ServerTool{
+		Tool: mcp.NewTool("failing", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	write := server.ServerTool{
+		Tool: mcp.NewTool("write", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(false)})),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			t.Fatal("write tools must not be called")
+			return nil, nil
+		},
+	}
+	hidden := server.ServerTool{
+		Tool: mcp.NewTool("hidden", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			t.Fatal("tools of disabled toolsets must not be called")
+			return nil, nil
+		},
+	}
+
+	enabled := toolsets.NewToolset("enabled", "Enabled toolset").AddReadTools(echo, failing).AddWriteTools(write)
+	enabled.Enabled = true
+	tsg.AddToolset(enabled)
+	tsg.AddToolset(toolsets.NewToolset("disabled", "Disabled toolset").AddReadTools(hidden))
+
+	_, handler := BatchRead(tsg, translations.NullTranslationHelper)
+
+	tests := []struct {
+		name            string
+		calls           []any
+		expectToolError bool
+		expectedErrMsg  string
+		expectedResults []batchCallResult
+	}{
+		{
+			name: "runs calls and keeps their order",
+			calls: []any{
+				map[string]any{"tool": "echo", "arguments": map[string]any{"value": "one"}},
+				map[string]any{"tool": "echo", "arguments": map[string]any{"value": "two"}},
+				map[string]any{"tool": "echo"},
+				map[string]any{"tool": "failing"},
+			},
+			expectedResults: []batchCallResult{
+				{Tool: "echo", Content: []mcp.Content{mcp.NewTextContent("one")}},
+				{Tool: "echo", Content: []mcp.Content{mcp.NewTextContent("two")}},
+				{Tool: "echo", IsError: true, Content: []mcp.Content{mcp.NewTextContent("missing required parameter: value")}},
+				{Tool: "failing", IsError: true, Error: "boom"},
+			},
+		},
+		{
+			name:            "rejects write tools",
+			calls:           []any{map[string]any{"tool": "echo"}, map[string]any{"tool": "write"}},
+			expectToolError: true,
+			expectedErrMsg:  "call 1: tool write is not read-only",
+		},
+		{
+			name:            "rejects tools that are not enabled",
+			calls:           []any{map[string]any{"tool": "hidden"}},
+			expectToolError: true,
+			expectedErrMsg:  "call 0: tool hidden is not enabled",
+		},
+		{
+			name:            "rejects empty batches",
+			calls:           []any{},
+			expectToolError: true,
+			expectedErrMsg:  "calls must not be empty",
+		},
+		{
+			name:            "rejects invalid arguments",
+			calls:           []any{map[string]any{"tool": "echo", "arguments": "value"}},
+			expectToolError: true,
+			expectedErrMsg:  "call 0: arguments must be an object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"calls": tc.calls}))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			require.False(t, result.IsError)
+			expected, err := json.Marshal(tc.expectedResults)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_BatchRead_Cancelled(t *testing.T) {
+	var started atomic.Int32
+	running := make(chan struct{}, MaxBatchCalls)
+	blocking := server.ServerTool{
+		Tool: mcp.NewTool("blocking", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			started.Add(1)
+			running <- struct{}{}
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	tsg := toolsets.NewToolsetGroup(false)
+	toolset := toolsets.NewToolset("blocking", "Blocking toolset").AddReadTools(blocking)
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+	_, handler := BatchRead(tsg, translations.NullTranslationHelper)
+
+	calls := make([]any, maxConcurrentBatchCalls+2)
+	for i := range calls {
+		calls[i] = map[string]any{"tool": "blocking"}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// Cancel once the first calls are running and the others wait for their turn
+		for i := 0; i < maxConcurrentBatchCalls; i++ {
+			<-running
+		}
+		cancel()
+	}()
+
+	result, err := handler(ctx, createMCPRequest(map[string]any{"calls": calls}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var results []batchCallResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &results))
+	require.Len(t, results, len(calls))
+	for _, r := range results {
+		assert.True(t, r.IsError)
+		assert.Equal(t, context.Canceled.Error(), r.Error)
+	}
+	assert.Equal(t, int32(maxConcurrentBatchCalls), started.Load(), "calls waiting for their turn must not start after cancellation")
+}
@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// BlamePullRequest is the pull request a blamed commit was merged with.
+type BlamePullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+// BlameCommit is the commit that last changed a range of lines.
+type BlameCommit struct {
+	SHA         string            `json:"sha"`
+	Message     string            `json:"message"`
+	Author      string            `json:"author,omitempty"`
+	AuthorLogin string            `json:"author_login,omitempty"`
+	Date        string            `json:"date"`
+	URL         string            `json:"url"`
+	PullRequest *BlamePullRequest `json:"pull_request,omitempty"`
+}
+
+// BlameRange is a range of lines that were last changed by the same commit.
+type BlameRange struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+	// Age is the recency of the change from 1 (newest) to 10 (oldest)
+	Age    int         `json:"age"`
+	Commit BlameCommit `json:"commit"`
+}
+
+// FileBlame is the result of get_file_blame.
+type FileBlame struct {
+	Path   string       `json:"path"`
+	Ref    string       `json:"ref"`
+	SHA    string       `json:"sha"`
+	Ranges []BlameRange `json:"ranges"`
+}
+
+// blameRangeFragment is the GraphQL selection of a blame range.
+type blameRangeFragment struct {
+	StartingLine githubv4.Int
+	EndingLine   githubv4.Int
+	Age          githubv4.Int
+	Commit       struct {
+		OID             githubv4.GitObjectID `graphql:"oid"`
+		MessageHeadline githubv4.String
+		AuthoredDate    githubv4.DateTime
+		URL             githubv4.String `graphql:"url"`
+		Author          struct {
+			Name githubv4.String
+			User struct {
+				Login githubv4.String
+			}
+		}
+		AssociatedPullRequests struct {
+			Nodes []struct {
+				Number githubv4.Int
+				Title  githubv4.String
+				URL    githubv4.String `graphql:"url"`
+			}
+		} `graphql:"associatedPullRequests(first: 1)"`
+	}
+}
+
+// GetFileBlame creates a tool to get the blame of a file, the commits that last changed its lines.
+func GetFileBlame(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_blame",
+			mcp.WithDescription(t("TOOL_GET_FILE_BLAME_DESCRIPTION", "Get the blame of a file in a GitHub repository: ranges of lines with the commit, author, date and pull request that last changed them")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_BLAME_USER_TITLE", "Get file blame"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch name, tag name or commit SHA to blame the file at. Defaults to the default branch"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line to blame, 1-based"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line to blame, inclusive"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ref == "" {
+				ref = "HEAD"
+			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if startLine > 0 && endLine > 0 && endLine < startLine {
+				return mcp.NewToolResultError("end_line must not be before start_line"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				Repository struct {
+					Object struct {
+						Commit struct {
+							OID   githubv4.GitObjectID `graphql:"oid"`
+							Blame struct {
+								Ranges []blameRangeFragment
+							} `graphql:"blame(path: $path)"`
+						} `graphql:"... on Commit"`
+					} `graphql:"object(expression: $ref)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"ref":   githubv4.String(ref),
+				"path":  githubv4.String(path),
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to get blame of %s", path), err), nil
+			}
+			commit := q.Repository.Object.Commit
+			if commit.OID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("ref %q does not resolve to a commit", ref)), nil
+			}
+
+			result := FileBlame{Path: path, Ref: ref, SHA: string(commit.OID), Ranges: []BlameRange{}}
+			for _, r := range commit.Blame.Ranges {
+				blameRange := convertBlameRange(r)
+				// Clip the ranges to the requested lines
+				if startLine > 0 {
+					if blameRange.EndLine < startLine {
+						continue
+					}
+					blameRange.StartLine = max(blameRange.StartLine, startLine)
+				}
+				if endLine > 0 {
+					if blameRange.StartLine > endLine {
+						continue
+					}
+					blameRange.EndLine = min(blameRange.EndLine, endLine)
+				}
+				result.Ranges = append(result.Ranges, blameRange)
+			}
+
+			out, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal blame: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+func convertBlameRange(r blameRangeFragment) BlameRange {
+	blameRange := BlameRange{
+		StartLine: int(r.StartingLine),
+		EndLine:   int(r.EndingLine),
+		Age:       int(r.Age),
+		Commit: BlameCommit{
+			SHA:         string(r.Commit.OID),
+			Message:     string(r.Commit.MessageHeadline),
+			Author:      string(r.Commit.Author.Name),
+			AuthorLogin: string(r.Commit.Author.User.Login),
+			Date:        r.Commit.AuthoredDate.Format("2006-01-02T15:04:05Z"),
+			URL:         string(r.Commit.URL),
+		},
+	}
+	if prs := r.Commit.AssociatedPullRequests.Nodes; len(prs) > 0 {
+		blameRange.Commit.PullRequest = &BlamePullRequest{
+			Number: int(prs[0].Number),
+			Title:  string(prs[0].Title),
+			URL:    string(prs[0].URL),
+		}
+	}
+	return blameRange
+}
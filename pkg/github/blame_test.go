@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetFileBlame(t *testing.T) {
+	// Verify tool definition and schema
+	tool, _ := GetFileBlame(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_file_blame", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	// Use exact string query that matches implementation output
+	qBlame := "query($owner:String!$path:String!$ref:String!$repo:String!){repository(owner: $owner, name: $repo){object(expression: $ref){... on Commit{oid,blame(path: $path){ranges{startingLine,endingLine,age,commit{oid,messageHeadline,authoredDate,url,author{name,user{login}},associatedPullRequests(first: 1){nodes{number,title,url}}}}}}}}}"
+
+	blameResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"object": map[string]any{
+			"oid": "head-sha",
+			"blame": map[string]any{"ranges": []map[string]any{
+				{
+					"startingLine": 1, "endingLine": 10, "age": 10,
+					"commit": map[string]any{
+						"oid": "old-sha", "messageHeadline": "Initial commit", "authoredDate": "2024-01-02T03:04:05Z",
+						"url":                    "https://github.com/owner/repo/commit/old-sha",
+						"author":                 map[string]any{"name": "Mona Lisa", "user": map[string]any{"login": "monalisa"}},
+						"associatedPullRequests": map[string]any{"nodes": []any{}},
+					},
+				},
+				{
+					"startingLine": 11, "endingLine": 15, "age": 1,
+					"commit": map[string]any{
+						"oid": "new-sha", "messageHeadline": "Fix regression", "authoredDate": "2025-06-07T08:09:10Z",
+						"url":    "https://github.com/owner/repo/commit/new-sha",
+						"author": map[string]any{"name": "Someone", "user": nil},
+						"associatedPullRequests": map[string]any{"nodes": []any{
+							map[string]any{"number": 42, "title": "Fix regression", "url": "https://github.com/owner/repo/pull/42"},
+						}},
+					},
+				},
+				{
+					"startingLine": 16, "endingLine": 30, "age": 5,
+					"commit": map[string]any{
+						"oid": "mid-sha", "messageHeadline": "Refactor", "authoredDate": "2024-09-01T00:00:00Z",
+						"url":                    "https://github.com/owner/repo/commit/mid-sha",
+						"author":                 map[string]any{"name": "Mona Lisa", "user": map[string]any{"login": "monalisa"}},
+						"associatedPullRequests": map[string]any{"nodes": []any{}},
+					},
+				},
+			}},
+		}},
+	})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		vars           map[string]any
+		response       githubv4mock.GQLResponse
+		expectedRanges [][2]int
+		expectedErrMsg string
+	}{
+		{
+			name:           "whole file at the default branch",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "path": "main.go"},
+			vars:           map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "ref": "HEAD"},
+			response:       blameResponse,
+			expectedRanges: [][2]int{{1, 10}, {11, 15}, {16, 30}},
+		},
+		{
+			name:           "clipped to a line range",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "ref": "v1.0.0", "start_line": float64(12), "end_line": float64(20)},
+			vars:           map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "ref": "v1.0.0"},
+			response:       blameResponse,
+			expectedRanges: [][2]int{{12, 15}, {16, 20}},
+		},
+		{
+			name:           "unknown ref",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "ref": "missing"},
+			vars:           map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "ref": "missing"},
+			response:       githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"object": nil}}),
+			expectedErrMsg: `ref "missing" does not resolve to a commit`,
+		},
+		{
+			name:           "unknown file",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "path": "missing.go"},
+			vars:           map[string]any{"owner": "owner", "repo": "repo", "path": "missing.go", "ref": "HEAD"},
+			response:       githubv4mock.ErrorResponse("Could not resolve file for path 'missing.go'."),
+			expectedErrMsg: "failed to get blame of missing.go",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := githubv4mock.NewQueryMatcher(qBlame, tc.vars, tc.response)
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+			_, handler := GetFileBlame(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var blame FileBlame
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &blame))
+			assert.Equal(t, "head-sha", blame.SHA)
+			ranges := make([][2]int, 0, len(blame.Ranges))
+			for _, r := range blame.Ranges {
+				ranges = append(ranges, [2]int{r.StartLine, r.EndLine})
+			}
+			assert.Equal(t, tc.expectedRanges, ranges)
+		})
+	}
+
+	t.Run("commit attribution", func(t *testing.T) {
+		vars := map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "ref": "HEAD"}
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qBlame, vars, blameResponse)))
+		_, handler := GetFileBlame(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "path": "main.go"}))
+		require.NoError(t, err)
+		var blame FileBlame
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &blame))
+
+		assert.Equal(t, BlameCommit{
+			SHA:         "old-sha",
+			Message:     "Initial commit",
+			Author:      "Mona Lisa",
+			AuthorLogin: "monalisa",
+			Date:        "2024-01-02T03:04:05Z",
+			URL:         "https://github.com/owner/repo/commit/old-sha",
+		}, blame.Ranges[0].Commit)
+		assert.Equal(t, 10, blame.Ranges[0].Age)
+		assert.Equal(t, &BlamePullRequest{Number: 42, Title: "Fix regression", URL: "https://github.com/owner/repo/pull/42"}, blame.Ranges[1].Commit.PullRequest)
+		assert.Empty(t, blame.Ranges[1].Commit.AuthorLogin)
+	})
+}
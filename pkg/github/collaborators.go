@@ -0,0 +1,413 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// permissionDescription describes the permission parameter of the access management tools.
+const permissionDescription = "Permission to grant: pull, triage, push, maintain, admin, or the name of a custom repository role of the organization"
+
+// Collaborator is a user with access to a repository and the permission they have.
+type Collaborator struct {
+	Login string `json:"login"`
+	// Permission is the role of the user, such as read, write or admin, or a custom role
+	Permission string `json:"permission"`
+	HTMLURL    string `json:"html_url"`
+}
+
+// TeamAccess is a team with access to a repository and the permission it grants.
+type TeamAccess struct {
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	Permission string `json:"permission"`
+	HTMLURL    string `json:"html_url"`
+}
+
+// RepositoryAccess is the result of list_repository_collaborators.
+type RepositoryAccess struct {
+	Collaborators []Collaborator `json:"collaborators"`
+	// Teams is omitted for repositories owned by users, which have no teams, and on pages after the first
+	Teams []TeamAccess `json:"teams,omitempty"`
+	// Note explains why the teams are missing when they could not be listed
+	Note string `json:"note,omitempty"`
+}
+
+// ListRepositoryCollaborators creates a tool to list the users and teams with access to a repository.
+func ListRepositoryCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_COLLABORATORS_DESCRIPTION", "List the collaborators of a GitHub repository with their permission levels, and the teams that have access to it. Collaborators include organization members with access through teams or base permissions unless filtered by affiliation")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_COLLABORATORS_USER_TITLE", "List repository collaborators"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("affiliation",
+				mcp.Description("Filter collaborators by affiliation: outside collaborators, direct collaborators, or all. Default is all"),
+				mcp.Enum("outside", "direct", "all"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Only list collaborators with this permission"),
+				mcp.Enum("pull", "triage", "push", "maintain", "admin"),
+			),
+			mcp.WithBoolean("include_teams",
+				mcp.Description("Include the teams with access to the repository on the first page. Default is true"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			affiliation, err := OptionalParam[string](request, "affiliation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeTeams := true
+			if v, ok, err := OptionalParamOK[bool](request, "include_teams"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				includeTeams = v
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{
+				Affiliation: affiliation,
+				Permission:  permission,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list collaborators",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := RepositoryAccess{Collaborators: make([]Collaborator, 0, len(users))}
+			for _, user := range users {
+				result.Collaborators = append(result.Collaborators, Collaborator{
+					Login:      user.GetLogin(),
+					Permission: user.GetRoleName(),
+					HTMLURL:    user.GetHTMLURL(),
+				})
+			}
+
+			// Teams are few compared to collaborators, so all of them are listed with the first page
+			if includeTeams && pagination.Page <= 1 {
+				opts := &github.ListOptions{PerPage: 100}
+				for {
+					teams, resp, err := client.Repositories.ListTeams(ctx, owner, repo, opts)
+					if resp != nil && resp.StatusCode == http.StatusNotFound && opts.Page == 0 {
+						// Repositories owned by users have no teams
+						break
+					}
+					if resp != nil && resp.StatusCode == http.StatusForbidden {
+						// Listing teams requires more access than listing collaborators
+						result.Teams = nil
+						result.Note = "Teams could not be listed, the token lacks permission to read the teams of the repository"
+						break
+					}
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to list teams",
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					for _, team := range teams {
+						result.Teams = append(result.Teams, TeamAccess{
+							Slug:       team.GetSlug(),
+							Name:       team.GetName(),
+							Permission: team.GetPermission(),
+							HTMLURL:    team.GetHTMLURL(),
+						})
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AddCollaboratorResult is the result of add_collaborator.
+type AddCollaboratorResult struct {
+	Username   string `json:"username"`
+	Permission string `json:"permission"`
+	// Invited is true when the user was invited, and false when the permission of an
+	// existing collaborator was changed
+	Invited       bool   `json:"invited"`
+	InvitationID  int64  `json:"invitation_id,omitempty"`
+	InvitationURL string `json:"invitation_url,omitempty"`
+}
+
+// AddCollaborator creates a tool to add a collaborator to a repository or change their permission.
+func AddCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_collaborator",
+			mcp.WithDescription(t("TOOL_ADD_COLLABORATOR_DESCRIPTION", "Add a user as a collaborator to a GitHub repository, or change the permission of an existing collaborator. New collaborators are invited and get access when they accept the invitation")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_COLLABORATOR_USER_TITLE", "Add collaborator"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the collaborator"),
+			),
+			mcp.WithString("permission",
+				mcp.Description(permissionDescription+". Default is push"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if permission == "" {
+				permission = "push"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitation, resp, err := client.Repositories.AddCollaborator(ctx, owner, repo, username, &github.RepositoryAddCollaboratorOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to add collaborator: %s", username),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := AddCollaboratorResult{
+				Username:   username,
+				Permission: permission,
+			}
+			// The API answers with no content when the user already is a collaborator
+			if resp.StatusCode == http.StatusCreated && invitation != nil {
+				result.Invited = true
+				result.InvitationID = invitation.GetID()
+				result.InvitationURL = invitation.GetHTMLURL()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RemoveCollaborator creates a tool to remove a collaborator from a repository.
+func RemoveCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_collaborator",
+			mcp.WithDescription(t("TOOL_REMOVE_COLLABORATOR_DESCRIPTION", "Remove a collaborator from a GitHub repository. Access the user has through teams or organization base permissions is not removed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_COLLABORATOR_USER_TITLE", "Remove collaborator"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the collaborator"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.RemoveCollaborator(ctx, owner, repo, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to remove collaborator: %s", username),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("collaborator %s removed from %s/%s", username, owner, repo)), nil
+		}
+}
+
+// SetTeamRepositoryPermission creates a tool to set or remove the access of a team to a repository.
+func SetTeamRepositoryPermission(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_team_repository_permission",
+			mcp.WithDescription(t("TOOL_SET_TEAM_REPOSITORY_PERMISSION_DESCRIPTION", "Set the permission a team has on a GitHub repository, adding the repository to the team if needed, or remove the access of the team with permission none")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_TEAM_REPOSITORY_PERMISSION_USER_TITLE", "Set team repository permission"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+			mcp.WithString("org",
+				mcp.Description("Organization of the team. Default is the repository owner"),
+			),
+			mcp.WithString("permission",
+				mcp.Required(),
+				mcp.Description(permissionDescription+", or none to remove the access of the team"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if org == "" {
+				org = owner
+			}
+			permission, err := RequiredParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if permission == "none" {
+				resp, err := client.Teams.RemoveTeamRepoBySlug(ctx, org, teamSlug, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to remove team from repository: %s", teamSlug),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return mcp.NewToolResultText(fmt.Sprintf("team %s/%s no longer has access to %s/%s", org, teamSlug, owner, repo)), nil
+			}
+
+			resp, err := client.Teams.AddTeamRepoBySlug(ctx, org, teamSlug, owner, repo, &github.TeamAddTeamRepoOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to set team repository permission: %s", teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("team %s/%s has %s permission on %s/%s", org, teamSlug, permission, owner, repo)), nil
+		}
+}
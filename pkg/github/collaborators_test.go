@@ -0,0 +1,418 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepositoryCollaborators(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_collaborators", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "affiliation")
+	assert.Contains(t, tool.InputSchema.Properties, "include_teams")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockUsers := []*github.User{
+		{Login: github.Ptr("alice"), RoleName: github.Ptr("admin"), HTMLURL: github.Ptr("https://github.com/alice")},
+		{Login: github.Ptr("bob"), RoleName: github.Ptr("write"), HTMLURL: github.Ptr("https://github.com/bob")},
+	}
+	mockTeams := []*github.Team{
+		{Slug: github.Ptr("core"), Name: github.Ptr("Core"), Permission: github.Ptr("maintain")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectedAccess RepositoryAccess
+		expectedErrMsg string
+	}{
+		{
+			name: "collaborators and teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"affiliation": "direct",
+						"page":        "1",
+						"per_page":    "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockUsers),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposTeamsByOwnerByRepo,
+					mockTeams,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"affiliation": "direct",
+			},
+			expectedAccess: RepositoryAccess{
+				Collaborators: []Collaborator{
+					{Login: "alice", Permission: "admin", HTMLURL: "https://github.com/alice"},
+					{Login: "bob", Permission: "write", HTMLURL: "https://github.com/bob"},
+				},
+				Teams: []TeamAccess{
+					{Slug: "core", Name: "Core", Permission: "maintain"},
+				},
+			},
+		},
+		{
+			name: "repository owned by a user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockUsers[:1],
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposTeamsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedAccess: RepositoryAccess{
+				Collaborators: []Collaborator{
+					{Login: "alice", Permission: "admin", HTMLURL: "https://github.com/alice"},
+				},
+			},
+		},
+		{
+			name: "teams are only listed on the first page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockUsers[1:],
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"page":  float64(2),
+			},
+			expectedAccess: RepositoryAccess{
+				Collaborators: []Collaborator{
+					{Login: "bob", Permission: "write", HTMLURL: "https://github.com/bob"},
+				},
+			},
+		},
+		{
+			name: "teams without permission",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockUsers[:1],
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposTeamsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedAccess: RepositoryAccess{
+				Collaborators: []Collaborator{
+					{Login: "alice", Permission: "admin", HTMLURL: "https://github.com/alice"},
+				},
+				Note: "Teams could not be listed, the token lacks permission to read the teams of the repository",
+			},
+		},
+		{
+			name: "without teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					[]*github.User{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"include_teams": false,
+			},
+			expectedAccess: RepositoryAccess{Collaborators: []Collaborator{}},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have push access to view repository collaborators."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedErrMsg: "failed to list collaborators",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var access RepositoryAccess
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &access))
+			assert.Equal(t, tc.expectedAccess, access)
+		})
+	}
+}
+
+func Test_AddCollaborator(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_collaborator", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectedResult AddCollaboratorResult
+		expectedErrMsg string
+	}{
+		{
+			name: "invite new collaborator",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectPath(t, "/repos/owner/repo/collaborators/carol").andThen(
+						expectRequestBody(t, map[string]any{"permission": "push"}).andThen(
+							mockResponse(t, http.StatusCreated, &github.CollaboratorInvitation{
+								ID:      github.Ptr(int64(42)),
+								HTMLURL: github.Ptr("https://github.com/owner/repo/invitations"),
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "carol",
+			},
+			expectedResult: AddCollaboratorResult{
+				Username:      "carol",
+				Permission:    "push",
+				Invited:       true,
+				InvitationID:  42,
+				InvitationURL: "https://github.com/owner/repo/invitations",
+			},
+		},
+		{
+			name: "change permission of existing collaborator",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "maintain"}).andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusNoContent)
+						},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "bob",
+				"permission": "maintain",
+			},
+			expectedResult: AddCollaboratorResult{
+				Username:   "bob",
+				Permission: "maintain",
+			},
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "ghost",
+			},
+			expectedErrMsg: "failed to add collaborator: ghost",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var added AddCollaboratorResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &added))
+			assert.Equal(t, tc.expectedResult, added)
+		})
+	}
+}
+
+func Test_RemoveCollaborator(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_collaborator", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposCollaboratorsByOwnerByRepoByUsername,
+			expectPath(t, "/repos/owner/repo/collaborators/bob").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := RemoveCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":    "owner",
+		"repo":     "repo",
+		"username": "bob",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "collaborator bob removed from owner/repo", getTextResult(t, result).Text)
+}
+
+func Test_SetTeamRepositoryPermission(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := SetTeamRepositoryPermission(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_team_repository_permission", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "team_slug", "permission"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "grant permission",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectPath(t, "/orgs/owner/teams/core/repos/owner/repo").andThen(
+						expectRequestBody(t, map[string]any{"permission": "maintain"}).andThen(
+							mockResponse(t, http.StatusNoContent, nil),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"team_slug":  "core",
+				"permission": "maintain",
+			},
+			expectedText: "team owner/core has maintain permission on owner/repo",
+		},
+		{
+			name: "remove access of team from another organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectPath(t, "/orgs/other/teams/core/repos/owner/repo").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"team_slug":  "core",
+				"org":        "other",
+				"permission": "none",
+			},
+			expectedText: "team other/core no longer has access to owner/repo",
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"team_slug":  "missing",
+				"permission": "pull",
+			},
+			expectedErrMsg: "failed to set team repository permission: missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetTeamRepositoryPermission(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,408 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// CompletionTTL is how long the API lookups used for argument completion are cached.
+	CompletionTTL = 5 * time.Minute
+	// maxCompletionValues is the maximum number of values of a completion result allowed by MCP.
+	maxCompletionValues = 100
+	// maxCompletionCacheEntries bounds the cache, as every directory completed adds an entry.
+	maxCompletionCacheEntries = 1000
+)
+
+// CompletionRef identifies the prompt or resource template of the argument to complete.
+type CompletionRef struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+}
+
+type completionCacheEntry struct {
+	values  []string
+	expires time.Time
+}
+
+// Completer suggests values for the arguments of prompts and resource templates, such as owners,
+// repositories, branches, tags, pull request numbers and file paths. API lookups are cached, as
+// clients request completions as the user types.
+type Completer struct {
+	getClient   GetClientFn
+	repoChecker *RepoPermissionChecker
+	repoContext *RepositoryContext
+
+	mu    sync.Mutex
+	cache map[string]completionCacheEntry
+	now   func() time.Time
+}
+
+// NewCompleter creates a Completer. Repositories the repoChecker does not allow are neither
+// suggested nor looked into. Owners and repositories that are not given as arguments default to
+// the repository context, if it is not nil.
+func NewCompleter(getClient GetClientFn, repoChecker *RepoPermissionChecker, repoContext *RepositoryContext) *Completer {
+	return &Completer{
+		getClient:   getClient,
+		repoChecker: repoChecker,
+		repoContext: repoContext,
+		cache:       make(map[string]completionCacheEntry),
+		now:         time.Now,
+	}
+}
+
+// Complete returns the completion of the argument with the given name and partial value. The
+// arguments already provided for the prompt or resource template narrow down the lookups.
+func (c *Completer) Complete(ctx context.Context, ref CompletionRef, name, value string, arguments map[string]string) (*mcp.CompleteResult, error) {
+	owner, repo := arguments["owner"], arguments["repo"]
+	if c.repoContext != nil {
+		if defaultRef, ok := c.repoContext.Get(ctx); ok {
+			if owner == "" {
+				owner = defaultRef.Owner
+			}
+			if repo == "" && strings.EqualFold(owner, defaultRef.Owner) {
+				repo = defaultRef.Repo
+			}
+		}
+	}
+
+	var candidates []string
+	var err error
+	switch {
+	case name == "owner":
+		candidates, err = c.owners(ctx)
+	case name == "repo" && ref.Type == "ref/prompt" && ref.Name == "AssignCodingAgent":
+		// This prompt takes the repository as owner/repo
+		candidates, err = c.fullNames(ctx, value)
+	case name == "repo" && owner != "":
+		candidates, err = c.repositories(ctx, owner)
+	case owner == "" || repo == "":
+		// Everything else is within a repository
+	case !c.repoAllowed(ctx, owner, repo):
+		// Nothing is completed within repositories that may not be accessed
+	case name == "branch":
+		candidates, err = c.branches(ctx, owner, repo)
+	case name == "tag":
+		candidates, err = c.tags(ctx, owner, repo)
+	case name == "sha":
+		candidates, err = c.commits(ctx, owner, repo)
+	case name == "prNumber" || name == "pullNumber":
+		candidates, err = c.pullRequests(ctx, owner, repo)
+	case name == "path":
+		candidates, err = c.paths(ctx, owner, repo, contentRef(arguments), value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return completionResult(filterCompletions(candidates, value)), nil
+}
+
+// repoAllowed reports whether the repository may be accessed. A failed check is treated as denied.
+func (c *Completer) repoAllowed(ctx context.Context, owner, repo string) bool {
+	return c.repoChecker == nil || c.repoChecker.IsRepoAllowed(ctx, owner, repo) == nil
+}
+
+// contentRef returns the git ref of the contents a resource template points to.
+func contentRef(arguments map[string]string) string {
+	switch {
+	case arguments["branch"] != "":
+		return "refs/heads/" + arguments["branch"]
+	case arguments["tag"] != "":
+		return "refs/tags/" + arguments["tag"]
+	case arguments["sha"] != "":
+		return arguments["sha"]
+	case arguments["prNumber"] != "":
+		return "refs/pull/" + arguments["prNumber"] + "/head"
+	default:
+		return ""
+	}
+}
+
+// filterCompletions returns the candidates that start with value, followed by those that only
+// contain it, ignoring case.
+func filterCompletions(candidates []string, value string) []string {
+	value = strings.ToLower(value)
+	var prefixed, containing []string
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		switch {
+		case strings.HasPrefix(lower, value):
+			prefixed = append(prefixed, candidate)
+		case strings.Contains(lower, value):
+			containing = append(containing, candidate)
+		}
+	}
+	return append(prefixed, containing...)
+}
+
+func completionResult(values []string) *mcp.CompleteResult {
+	result := &mcp.CompleteResult{}
+	result.Completion.Values = values
+	if result.Completion.Values == nil {
+		result.Completion.Values = []string{}
+	}
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		result.Completion.Values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
+	}
+	return result
+}
+
+// cached returns the cached values for key, or looks them up with fn and caches them.
+func (c *Completer) cached(key string, fn func() ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	if ok && !c.now().Before(entry.expires) {
+		delete(c.cache, key)
+		ok = false
+	}
+	c.mu.Unlock()
+	if ok {
+		return entry.values, nil
+	}
+
+	values, err := fn()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if len(c.cache) >= maxCompletionCacheEntries {
+		c.evictLocked()
+	}
+	c.cache[key] = completionCacheEntry{values: values, expires: c.now().Add(CompletionTTL)}
+	c.mu.Unlock()
+	return values, nil
+}
+
+// evictLocked removes the expired entries of the cache or, if none has expired, the entry that
+// expires first, to make room for a new entry.
+func (c *Completer) evictLocked() {
+	now := c.now()
+	oldest := ""
+	for key, entry := range c.cache {
+		if !now.Before(entry.expires) {
+			delete(c.cache, key)
+			continue
+		}
+		if oldest == "" || entry.expires.Before(c.cache[oldest].expires) {
+			oldest = key
+		}
+	}
+	if len(c.cache) >= maxCompletionCacheEntries {
+		delete(c.cache, oldest)
+	}
+}
+
+// owners returns the authenticated user and their organizations.
+func (c *Completer) owners(ctx context.Context) ([]string, error) {
+	return c.cached("owners", func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		user, resp, err := client.Users.Get(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		owners := []string{user.GetLogin()}
+		orgs, resp, err := client.Organizations.List(ctx, "", &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, org := range orgs {
+			owners = append(owners, org.GetLogin())
+		}
+		return owners, nil
+	})
+}
+
+// repositories returns the names of the most recently updated repositories of an owner that may
+// be accessed.
+func (c *Completer) repositories(ctx context.Context, owner string) ([]string, error) {
+	names, err := c.cached("repos:"+strings.ToLower(owner), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		// Search covers users and organizations alike, including the private repositories the token can access
+		result, resp, err := client.Search.Repositories(ctx, fmt.Sprintf("user:%s fork:true", owner), &github.SearchOptions{
+			Sort:        "updated",
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search repositories of %s: %w", owner, err)
+		}
+		_ = resp.Body.Close()
+
+		names := make([]string, 0, len(result.Repositories))
+		for _, repo := range result.Repositories {
+			names = append(names, repo.GetName())
+		}
+		return names, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make([]string, 0, len(names))
+	for _, name := range names {
+		if c.repoAllowed(ctx, owner, name) {
+			allowed = append(allowed, name)
+		}
+	}
+	return allowed, nil
+}
+
+// fullNames completes owner/repo values, owners first and then the repositories of the owner.
+func (c *Completer) fullNames(ctx context.Context, value string) ([]string, error) {
+	owner, _, found := strings.Cut(value, "/")
+	if !found {
+		owners, err := c.owners(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(owners))
+		for _, owner := range owners {
+			values = append(values, owner+"/")
+		}
+		return values, nil
+	}
+	repos, err := c.repositories(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		values = append(values, owner+"/"+repo)
+	}
+	return values, nil
+}
+
+func (c *Completer) branches(ctx context.Context, owner, repo string) ([]string, error) {
+	return c.cached(fmt.Sprintf("branches:%s/%s", owner, repo), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		names := make([]string, 0, len(branches))
+		for _, branch := range branches {
+			names = append(names, branch.GetName())
+		}
+		return names, nil
+	})
+}
+
+func (c *Completer) tags(ctx context.Context, owner, repo string) ([]string, error) {
+	return c.cached(fmt.Sprintf("tags:%s/%s", owner, repo), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		names := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			names = append(names, tag.GetName())
+		}
+		return names, nil
+	})
+}
+
+// commits returns the SHAs of the most recent commits of the default branch.
+func (c *Completer) commits(ctx context.Context, owner, repo string) ([]string, error) {
+	return c.cached(fmt.Sprintf("commits:%s/%s", owner, repo), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{ListOptions: github.ListOptions{PerPage: 30}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		shas := make([]string, 0, len(commits))
+		for _, commit := range commits {
+			shas = append(shas, commit.GetSHA())
+		}
+		return shas, nil
+	})
+}
+
+// pullRequests returns the numbers of the open pull requests, most recently updated first.
+func (c *Completer) pullRequests(ctx context.Context, owner, repo string) ([]string, error) {
+	return c.cached(fmt.Sprintf("pulls:%s/%s", owner, repo), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		pulls, resp, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State:       "open",
+			Sort:        "updated",
+			Direction:   "desc",
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		numbers := make([]string, 0, len(pulls))
+		for _, pull := range pulls {
+			numbers = append(numbers, strconv.Itoa(pull.GetNumber()))
+		}
+		return numbers, nil
+	})
+}
+
+// paths returns the entries of the directory of the partial path value. Directories end with a
+// slash, so that completing them continues with their entries.
+func (c *Completer) paths(ctx context.Context, owner, repo, ref, value string) ([]string, error) {
+	dir := ""
+	if i := strings.LastIndex(value, "/"); i >= 0 {
+		dir = value[:i]
+	}
+	return c.cached(fmt.Sprintf("paths:%s/%s@%s:%s", owner, repo, ref, dir), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		_, entries, resp, err := client.Repositories.GetContents(ctx, owner, repo, dir, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contents of %q: %w", dir, err)
+		}
+		_ = resp.Body.Close()
+
+		paths := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			path := entry.GetPath()
+			if entry.GetType() == "dir" {
+				path += "/"
+			}
+			paths = append(paths, path)
+		}
+		return paths, nil
+	})
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Completer(t *testing.T) {
+	contentsRequests := 0
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetUser, &github.User{Login: github.Ptr("octocat")}),
+		mock.WithRequestMatch(mock.GetUserOrgs, []*github.Organization{{Login: github.Ptr("github")}, {Login: github.Ptr("octo-org")}}),
+		mock.WithRequestMatchHandler(mock.GetSearchRepositories, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "user:octocat fork:true", r.URL.Query().Get("q"))
+			_, _ = w.Write(mock.MustMarshal(&github.RepositoriesSearchResult{
+				Total: github.Ptr(3),
+				Repositories: []*github.Repository{
+					{Name: github.Ptr("Spoon-Knife")},
+					{Name: github.Ptr("hello-world")},
+					{Name: github.Ptr("octocat.github.io")},
+				},
+			}))
+		})),
+		mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, []*github.Branch{
+			{Name: github.Ptr("main")},
+			{Name: github.Ptr("feature/main-menu")},
+			{Name: github.Ptr("release")},
+		}),
+		mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepo, []*github.PullRequest{{Number: github.Ptr(42)}, {Number: github.Ptr(7)}}),
+		mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentsRequests++
+			assert.Equal(t, "/repos/octocat/hello-world/contents/src", r.URL.Path)
+			assert.Equal(t, "refs/heads/main", r.URL.Query().Get("ref"))
+			_, _ = w.Write(mock.MustMarshal([]*github.RepositoryContent{
+				{Path: github.Ptr("src/main.go"), Type: github.Ptr("file")},
+				{Path: github.Ptr("src/internal"), Type: github.Ptr("dir")},
+			}))
+		})),
+	)
+	repoContext := NewRepositoryContext()
+	completer := NewCompleter(stubGetClientFn(github.NewClient(mockedClient)), nil, repoContext)
+	promptRef := CompletionRef{Type: "ref/prompt", Name: "IssueToFixWorkflow"}
+	contentsRef := CompletionRef{Type: "ref/resource", URI: "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"}
+
+	tests := []struct {
+		name      string
+		ref       CompletionRef
+		argument  string
+		value     string
+		arguments map[string]string
+		expected  []string
+	}{
+		{name: "owners", ref: promptRef, argument: "owner", value: "oct", expected: []string{"octocat", "octo-org"}},
+		{name: "owners by substring", ref: promptRef, argument: "owner", value: "hub", expected: []string{"github"}},
+		{name: "repositories", ref: promptRef, argument: "repo", value: "h", arguments: map[string]string{"owner": "octocat"}, expected: []string{"hello-world", "octocat.github.io"}},
+		{name: "repositories without owner", ref: promptRef, argument: "repo", value: "h", expected: []string{}},
+		{name: "owner/repo owners", ref: CompletionRef{Type: "ref/prompt", Name: "AssignCodingAgent"}, argument: "repo", value: "octo", expected: []string{"octocat/", "octo-org/"}},
+		{name: "owner/repo repositories", ref: CompletionRef{Type: "ref/prompt", Name: "AssignCodingAgent"}, argument: "repo", value: "octocat/sp", expected: []string{"octocat/Spoon-Knife"}},
+		{name: "branches prefixed first", ref: contentsRef, argument: "branch", value: "main", arguments: map[string]string{"owner": "octocat", "repo": "hello-world"}, expected: []string{"main", "feature/main-menu"}},
+		{name: "pull requests", ref: contentsRef, argument: "prNumber", value: "4", arguments: map[string]string{"owner": "octocat", "repo": "hello-world"}, expected: []string{"42"}},
+		{name: "paths", ref: contentsRef, argument: "path", value: "src/", arguments: map[string]string{"owner": "octocat", "repo": "hello-world", "branch": "main"}, expected: []string{"src/main.go", "src/internal/"}},
+		{name: "paths of the same directory are cached", ref: contentsRef, argument: "path", value: "src/int", arguments: map[string]string{"owner": "octocat", "repo": "hello-world", "branch": "main"}, expected: []string{"src/internal/"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := completer.Complete(context.Background(), tc.ref, tc.argument, tc.value, tc.arguments)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Completion.Values)
+			assert.Equal(t, len(tc.expected), result.Completion.Total)
+			assert.False(t, result.Completion.HasMore)
+		})
+	}
+	assert.Equal(t, 1, contentsRequests)
+
+	t.Run("repository context", func(t *testing.T) {
+		repoContext.Set(context.Background(), RepositoryRef{Owner: "octocat", Repo: "hello-world"})
+		result, err := completer.Complete(context.Background(), contentsRef, "branch", "rel", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"release"}, result.Completion.Values)
+	})
+
+	t.Run("cache expires", func(t *testing.T) {
+		completer.now = func() time.Time { return time.Now().Add(CompletionTTL) }
+		_, err := completer.Complete(context.Background(), contentsRef, "path", "src/", map[string]string{"owner": "octocat", "repo": "hello-world", "branch": "main"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, contentsRequests)
+	})
+}
+
+func Test_Completer_AllowedRepos(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetUser, &github.User{Login: github.Ptr("octocat")}),
+		mock.WithRequestMatch(mock.GetSearchRepositories, &github.RepositoriesSearchResult{
+			Total:        github.Ptr(2),
+			Repositories: []*github.Repository{{Name: github.Ptr("hello-world")}, {Name: github.Ptr("secret")}},
+		}),
+		mock.WithRequestMatchHandler(mock.GetReposBranchesByOwnerByRepo, http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			t.Error("branches of a repository that is not allowed must not be looked up")
+		})),
+	)
+	getClient := stubGetClientFn(github.NewClient(mockedClient))
+	completer := NewCompleter(getClient, NewRepoPermissionChecker([]string{"hello-world"}, getClient), nil)
+
+	result, err := completer.Complete(context.Background(), CompletionRef{Type: "ref/prompt", Name: "IssueToFixWorkflow"}, "repo", "", map[string]string{"owner": "octocat"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello-world"}, result.Completion.Values)
+
+	contentsRef := CompletionRef{Type: "ref/resource", URI: "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"}
+	result, err = completer.Complete(context.Background(), contentsRef, "branch", "", map[string]string{"owner": "octocat", "repo": "secret"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Completion.Values)
+}
+
+func Test_Completer_CacheBounded(t *testing.T) {
+	completer := NewCompleter(nil, nil, nil)
+	now := time.Now()
+	completer.now = func() time.Time { return now }
+	lookup := func() ([]string, error) { return []string{"value"}, nil }
+
+	_, _ = completer.cached("first", lookup)
+	now = now.Add(time.Second)
+	for i := 1; i < maxCompletionCacheEntries+10; i++ {
+		_, _ = completer.cached(strconv.Itoa(i), lookup)
+	}
+	assert.Len(t, completer.cache, maxCompletionCacheEntries)
+	assert.NotContains(t, completer.cache, "first", "the entry that expires first is evicted")
+
+	// Expired entries are removed when they are looked up
+	now = now.Add(CompletionTTL)
+	_, _ = completer.cached("1", func() ([]string, error) { return nil, errors.New("unavailable") })
+	assert.NotContains(t, completer.cache, "1")
+}
+
+func Test_Completer_Errors(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(mock.GetReposTagsByOwnerByRepo, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})),
+	)
+	completer := NewCompleter(stubGetClientFn(github.NewClient(mockedClient)), nil, nil)
+	ref := CompletionRef{Type: "ref/resource", URI: "repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}"}
+
+	_, err := completer.Complete(context.Background(), ref, "tag", "v", map[string]string{"owner": "octocat", "repo": "missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list tags")
+}
+
+func Test_CompletionResult(t *testing.T) {
+	values := make([]string, maxCompletionValues+20)
+	for i := range values {
+		values[i] = strconv.Itoa(i)
+	}
+	result := completionResult(values)
+	assert.Len(t, result.Completion.Values, maxCompletionValues)
+	assert.Equal(t, len(values), result.Completion.Total)
+	assert.True(t, result.Completion.HasMore)
+}
@@ -33,10 +33,17 @@ type UserDetails struct {
 	OwnedPrivateRepos int64     `json:"owned_private_repos,omitempty"`
 }
 
-// GetMe creates a tool to get details of the authenticated user.
-func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+// meResult is the result of get_me, the user together with what the token can do.
+type meResult struct {
+	MinimalUser
+	TokenAccess *TokenAccess `json:"token_access,omitempty"`
+}
+
+// GetMe creates a tool to get details of the authenticated user. The tokenAccess determined at
+// startup, if any, is reported alongside the user so that it is clear why tools may be missing.
+func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc, tokenAccess *TokenAccess) (mcp.Tool, server.ToolHandlerFunc) {
 	tool := mcp.NewTool("get_me",
-		mcp.WithDescription(t("TOOL_GET_ME_DESCRIPTION", "Get details of the authenticated GitHub user. Use this when a request is about the user's own profile for GitHub. Or when information is missing to build other tool calls.")),
+		mcp.WithDescription(t("TOOL_GET_ME_DESCRIPTION", "Get details of the authenticated GitHub user. Use this when a request is about the user's own profile for GitHub. Or when information is missing to build other tool calls. Also reports the token type, its scopes and the tools hidden because the token cannot use them.")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        t("TOOL_GET_ME_USER_TITLE", "Get my user profile"),
 			ReadOnlyHint: ToBoolPtr(true),
@@ -86,7 +93,7 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 			},
 		}
 
-		return MarshalledTextResult(minimalUser), nil
+		return MarshalledTextResult(meResult{MinimalUser: minimalUser, TokenAccess: tokenAccess}), nil
 	})
 
 	return tool, handler
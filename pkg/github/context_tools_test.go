@@ -20,7 +20,7 @@ import (
 func Test_GetMe(t *testing.T) {
 	t.Parallel()
 
-	tool, _ := GetMe(nil, translations.NullTranslationHelper)
+	tool, _ := GetMe(nil, translations.NullTranslationHelper, nil)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	// Verify some basic very important properties
@@ -108,7 +108,7 @@ func Test_GetMe(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			_, handler := GetMe(tc.stubbedGetClientFn, translations.NullTranslationHelper)
+			_, handler := GetMe(tc.stubbedGetClientFn, translations.NullTranslationHelper, nil)
 
 			request := createMCPRequest(tc.requestArgs)
 			result, err := handler(context.Background(), request)
@@ -141,6 +141,25 @@ func Test_GetMe(t *testing.T) {
 			assert.Equal(t, *tc.expectedUser.TwitterUsername, returnedUser.Details.TwitterUsername)
 		})
 	}
+
+	t.Run("reports token access", func(t *testing.T) {
+		access := NewClassicTokenAccess([]string{"read:org"})
+		access.HiddenTools = []HiddenTool{{Name: "create_issue", Reason: "requires one of the scopes: repo, public_repo"}}
+		_, handler := GetMe(stubGetClientFn(github.NewClient(
+			mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetUser, mockUser)),
+		)), translations.NullTranslationHelper, access)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+
+		var returned struct {
+			TokenAccess TokenAccess `json:"token_access"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		assert.Equal(t, TokenTypeClassic, returned.TokenAccess.TokenType)
+		assert.Equal(t, []string{"read:org"}, returned.TokenAccess.Scopes)
+		assert.Equal(t, access.HiddenTools, returned.TokenAccess.HiddenTools)
+	})
 }
 
 func Test_GetTeams(t *testing.T) {
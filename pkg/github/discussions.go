@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v74/github"
@@ -220,7 +221,7 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 
 			discussionQuery := getQueryType(useOrdering, categoryID)
 			if err := client.Query(ctx, discussionQuery, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list discussions", err), nil
 			}
 
 			// Extract and convert all discussion nodes using the common interface
@@ -311,7 +312,7 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 				"discussionNumber": githubv4.Int(params.DiscussionNumber),
 			}
 			if err := client.Query(ctx, &q, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion", err), nil
 			}
 			d := q.Repository.Discussion
 			discussion := &github.Discussion{
@@ -412,7 +413,7 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				vars["after"] = (*githubv4.String)(nil)
 			}
 			if err := client.Query(ctx, &q, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion comments", err), nil
 			}
 
 			var comments []*github.IssueComment
@@ -499,7 +500,7 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 				"first": githubv4.Int(25),
 			}
 			if err := client.Query(ctx, &q, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list discussion categories", err), nil
 			}
 
 			var categories []map[string]string
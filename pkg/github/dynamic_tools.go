@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -19,7 +21,149 @@ func ToolsetEnum(toolsetGroup *toolsets.ToolsetGroup) mcp.PropertyOption {
 	return mcp.Enum(toolsetNames...)
 }
 
-func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+// pinnedToolsets are never disabled to stay within maxActiveTools, as the repository context and
+// the tools to manage toolsets must remain available.
+var pinnedToolsets = map[string]bool{
+	"context": true,
+	"dynamic": true,
+}
+
+// ToolsetManager enables and disables toolsets at runtime in dynamic mode. It tracks when the
+// tools of each toolset were last used, so that when maxActiveTools is set the least recently
+// used toolsets can be disabled to keep the number of active tools bounded. It is the only writer
+// of the enabled state of the toolsets, others read it through the toolset group.
+type ToolsetManager struct {
+	server         *server.MCPServer
+	toolsetGroup   *toolsets.ToolsetGroup
+	maxActiveTools int
+
+	mu       sync.Mutex
+	clock    uint64
+	lastUsed map[string]uint64
+}
+
+// NewToolsetManager creates a ToolsetManager, a maxActiveTools of 0 means no limit.
+func NewToolsetManager(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, maxActiveTools int) *ToolsetManager {
+	m := &ToolsetManager{
+		server:         s,
+		toolsetGroup:   toolsetGroup,
+		maxActiveTools: maxActiveTools,
+		lastUsed:       make(map[string]uint64),
+	}
+	for name := range toolsetGroup.Toolsets {
+		if toolsetGroup.IsEnabled(name) {
+			m.touchLocked(name)
+		}
+	}
+	return m
+}
+
+func (m *ToolsetManager) touchLocked(name string) {
+	m.clock++
+	m.lastUsed[name] = m.clock
+}
+
+// TrackUsage returns a decorator that records each call of a tool as a use of its toolset.
+func (m *ToolsetManager) TrackUsage(toolsetName string) func(server.ServerTool) server.ServerTool {
+	return func(st server.ServerTool) server.ServerTool {
+		handler := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			m.mu.Lock()
+			m.touchLocked(toolsetName)
+			m.mu.Unlock()
+			return handler(ctx, request)
+		}
+		return st
+	}
+}
+
+// Enable enables a toolset and registers its tools, which notifies clients that the tool list
+// changed. It returns the toolsets that were disabled to stay within maxActiveTools.
+func (m *ToolsetManager) Enable(name string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	toolset, err := m.toolsetGroup.GetToolset(name)
+	if err != nil {
+		return nil, err
+	}
+	m.touchLocked(name)
+	if toolset.Enabled {
+		return nil, nil
+	}
+	if err := m.toolsetGroup.SetEnabled(name, true); err != nil {
+		return nil, err
+	}
+	m.server.AddTools(toolset.GetActiveTools()...)
+
+	return m.enforceLimitLocked(name), nil
+}
+
+// Disable disables a toolset and removes its tools, which notifies clients that the tool list changed.
+func (m *ToolsetManager) Disable(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.toolsetGroup.GetToolset(name); err != nil {
+		return err
+	}
+	m.disableLocked(name)
+	return nil
+}
+
+func (m *ToolsetManager) disableLocked(name string) {
+	toolset := m.toolsetGroup.Toolsets[name]
+	if !toolset.Enabled {
+		return
+	}
+	tools := toolset.GetActiveTools()
+	_ = m.toolsetGroup.SetEnabled(name, false)
+	delete(m.lastUsed, name)
+
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Tool.Name)
+	}
+	m.server.DeleteTools(names...)
+}
+
+// activeToolCountLocked returns the number of tools of all enabled toolsets.
+func (m *ToolsetManager) activeToolCountLocked() int {
+	count := 0
+	for _, toolset := range m.toolsetGroup.Toolsets {
+		count += len(toolset.GetActiveTools())
+	}
+	return count
+}
+
+// enforceLimitLocked disables the least recently used toolsets, other than keep and the pinned
+// toolsets, until the number of active tools is within maxActiveTools.
+func (m *ToolsetManager) enforceLimitLocked(keep string) []string {
+	if m.maxActiveTools <= 0 {
+		return nil
+	}
+	var disabled []string
+	for m.activeToolCountLocked() > m.maxActiveTools {
+		lru := ""
+		for name, toolset := range m.toolsetGroup.Toolsets {
+			if name == keep || pinnedToolsets[name] || !toolset.Enabled {
+				continue
+			}
+			if lru == "" || m.lastUsed[name] < m.lastUsed[lru] {
+				lru = name
+			}
+		}
+		if lru == "" {
+			// The remaining toolsets alone exceed the limit
+			break
+		}
+		m.disableLocked(lru)
+		disabled = append(disabled, lru)
+	}
+	return disabled
+}
+
+func EnableToolset(manager *ToolsetManager, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("enable_toolset",
 			mcp.WithDescription(t("TOOL_ENABLE_TOOLSET_DESCRIPTION", "Enable one of the sets of tools the GitHub MCP server provides, use get_toolset_tools and list_available_toolsets first to see what this will enable")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
@@ -43,22 +187,58 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 			if toolset == nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
 			}
-			if toolset.Enabled {
+			if toolsetGroup.IsEnabled(toolsetName) {
 				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil
 			}
 
-			toolset.Enabled = true
-
-			// caution: this currently affects the global tools and notifies all clients:
-			//
-			// Send notification to all initialized sessions
-			// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
-			s.AddTools(toolset.GetActiveTools()...)
+			// caution: this currently affects the global tools and notifies all clients
+			disabled, err := manager.Enable(toolsetName)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(disabled) > 0 {
+				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled, disabled least recently used toolsets to stay within %d active tools: %s", toolsetName, manager.maxActiveTools, strings.Join(disabled, ", "))), nil
+			}
 
 			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
 		}
 }
 
+func DisableToolset(manager *ToolsetManager, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_toolset",
+			mcp.WithDescription(t("TOOL_DISABLE_TOOLSET_DESCRIPTION", "Disable one of the enabled sets of tools the GitHub MCP server provides, removing its tools. Use this when the tools of a toolset are no longer needed for the task")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title: t("TOOL_DISABLE_TOOLSET_USER_TITLE", "Disable a toolset"),
+				// Not modifying GitHub data so no need to show a warning
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("toolset",
+				mcp.Required(),
+				mcp.Description("The name of the toolset to disable"),
+				ToolsetEnum(toolsetGroup),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			toolsetName, err := RequiredParam[string](request, "toolset")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolset := toolsetGroup.Toolsets[toolsetName]
+			if toolset == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
+			}
+			if !toolsetGroup.IsEnabled(toolsetName) {
+				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already disabled", toolsetName)), nil
+			}
+
+			if err := manager.Disable(toolsetName); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s disabled", toolsetName)), nil
+		}
+}
+
 func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_available_toolsets",
 			mcp.WithDescription(t("TOOL_LIST_AVAILABLE_TOOLSETS_DESCRIPTION", "List all available toolsets this GitHub MCP server can offer, providing the enabled status of each. Use this when a task could be achieved with a GitHub tool and the currently available tools aren't enough. Call get_toolset_tools with these toolset names to discover specific tools you can call")),
@@ -74,11 +254,16 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 
 			for name, ts := range toolsetGroup.Toolsets {
 				{
+					availableTools := len(ts.GetAvailableTools())
 					t := map[string]string{
 						"name":              name,
 						"description":       ts.Description,
-						"can_enable":        "true",
-						"currently_enabled": fmt.Sprintf("%t", ts.Enabled),
+						"can_enable":        fmt.Sprintf("%t", availableTools > 0),
+						"currently_enabled": fmt.Sprintf("%t", toolsetGroup.IsEnabled(name)),
+						"available_tools":   fmt.Sprintf("%d", availableTools),
+					}
+					if excluded := ts.ExcludedTools(); len(excluded) > 0 {
+						t["excluded_tools"] = strings.Join(excluded, ",")
 					}
 					payload = append(payload, t)
 				}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registeredToolNames lists the tools of the server as seen by clients.
+func registeredToolNames(t *testing.T, s *server.MCPServer) []string {
+	t.Helper()
+	response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var listed struct {
+		Result mcp.ListToolsResult `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(data, &listed))
+	var names []string
+	for _, tool := range listed.Result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// okTool returns a read-only tool that always succeeds.
+func okTool(name string) server.ServerTool {
+	tool := stubTool(name, true)
+	tool.Handler = func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	return tool
+}
+
+func newDynamicTestServer(t *testing.T, maxActiveTools int) (*server.MCPServer, *toolsets.ToolsetGroup, *toolsets.Toolset) {
+	t.Helper()
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issues").
+		AddReadTools(okTool("get_issue"), okTool("list_issues")))
+	tsg.AddToolset(toolsets.NewToolset("gists", "Gists").
+		AddReadTools(okTool("list_gists")))
+	tsg.AddToolset(toolsets.NewToolset("actions", "Actions").
+		AddReadTools(okTool("list_workflows")))
+
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	dynamic := InitDynamicToolset(s, tsg, translations.NullTranslationHelper, maxActiveTools)
+	tsg.RegisterAll(s)
+	dynamic.RegisterTools(s)
+	return s, tsg, dynamic
+}
+
+func callDynamicTool(t *testing.T, dynamic *toolsets.Toolset, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	for _, tool := range dynamic.GetActiveTools() {
+		if tool.Tool.Name == name {
+			result, err := tool.Handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			return result
+		}
+	}
+	t.Fatalf("tool %s not found", name)
+	return nil
+}
+
+func Test_DisableToolset(t *testing.T) {
+	tsg := toolsets.NewToolsetGroup(false)
+	tool, _ := DisableToolset(NewToolsetManager(server.NewMCPServer("test", "1.0.0"), tsg, 0), tsg, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "disable_toolset", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "toolset")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"toolset"})
+
+	t.Run("enable and disable update the registered tools", func(t *testing.T) {
+		s, _, dynamic := newDynamicTestServer(t, 0)
+		assert.NotContains(t, registeredToolNames(t, s), "get_issue")
+
+		result := callDynamicTool(t, dynamic, "enable_toolset", map[string]any{"toolset": "issues"})
+		assert.Equal(t, "Toolset issues enabled", getTextResult(t, result).Text)
+		assert.Subset(t, registeredToolNames(t, s), []string{"get_issue", "list_issues", "disable_toolset"})
+
+		result = callDynamicTool(t, dynamic, "disable_toolset", map[string]any{"toolset": "issues"})
+		assert.Equal(t, "Toolset issues disabled", getTextResult(t, result).Text)
+		names := registeredToolNames(t, s)
+		assert.NotContains(t, names, "get_issue")
+		assert.NotContains(t, names, "list_issues")
+
+		result = callDynamicTool(t, dynamic, "disable_toolset", map[string]any{"toolset": "issues"})
+		assert.Equal(t, "Toolset issues is already disabled", getTextResult(t, result).Text)
+	})
+
+	t.Run("unknown toolset", func(t *testing.T) {
+		_, _, dynamic := newDynamicTestServer(t, 0)
+		result := callDynamicTool(t, dynamic, "disable_toolset", map[string]any{"toolset": "unknown"})
+		require.True(t, result.IsError)
+		assert.Equal(t, "Toolset unknown not found", getErrorResult(t, result).Text)
+	})
+}
+
+func Test_ToolsetManager_MaxActiveTools(t *testing.T) {
+	s, tsg, dynamic := newDynamicTestServer(t, 3)
+
+	callDynamicTool(t, dynamic, "enable_toolset", map[string]any{"toolset": "issues"})
+	callDynamicTool(t, dynamic, "enable_toolset", map[string]any{"toolset": "gists"})
+
+	// Using an issues tool makes gists the least recently used toolset
+	response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_issue","arguments":{}}}`))
+	require.NotNil(t, response)
+
+	result := callDynamicTool(t, dynamic, "enable_toolset", map[string]any{"toolset": "actions"})
+	assert.Equal(t, "Toolset actions enabled, disabled least recently used toolsets to stay within 3 active tools: gists", getTextResult(t, result).Text)
+
+	assert.True(t, tsg.Toolsets["issues"].Enabled)
+	assert.False(t, tsg.Toolsets["gists"].Enabled)
+	assert.True(t, tsg.Toolsets["actions"].Enabled)
+
+	names := registeredToolNames(t, s)
+	assert.NotContains(t, names, "list_gists")
+	assert.Subset(t, names, []string{"get_issue", "list_issues", "list_workflows"})
+}
+
+func Test_ToolsetManager_PinnedToolsets(t *testing.T) {
+	tsg := toolsets.NewToolsetGroup(false)
+	contextTools := toolsets.NewToolset("context", "Context").AddReadTools(okTool("get_me"))
+	contextTools.Enabled = true
+	tsg.AddToolset(contextTools)
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issues").AddReadTools(okTool("get_issue")))
+
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	dynamic := InitDynamicToolset(s, tsg, translations.NullTranslationHelper, 1)
+	tsg.RegisterAll(s)
+	dynamic.RegisterTools(s)
+
+	// The context toolset is the least recently used one, but it is never disabled
+	result := callDynamicTool(t, dynamic, "enable_toolset", map[string]any{"toolset": "issues"})
+	assert.Equal(t, "Toolset issues enabled", getTextResult(t, result).Text)
+	assert.True(t, tsg.IsEnabled("context"))
+	assert.True(t, tsg.IsEnabled("issues"))
+}
+
+func Test_ToolsetManager_ConcurrentReads(t *testing.T) {
+	_, tsg, dynamic := newDynamicTestServer(t, 2)
+	batchRead := toolsets.NewServerTool(BatchRead(tsg, translations.NullTranslationHelper))
+
+	handlers := make(map[string]server.ToolHandlerFunc)
+	for _, tool := range dynamic.GetActiveTools() {
+		handlers[tool.Tool.Name] = tool.Handler
+	}
+	call := func(name string, args map[string]any) {
+		_, _ = handlers[name](context.Background(), createMCPRequest(args))
+	}
+
+	// Enabling and disabling toolsets while their state is read must not race, run with -race
+	var wg sync.WaitGroup
+	for _, toolset := range []string{"issues", "gists", "actions"} {
+		wg.Add(1)
+		go func(toolset string) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				call("enable_toolset", map[string]any{"toolset": toolset})
+				call("disable_toolset", map[string]any{"toolset": toolset})
+			}
+		}(toolset)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			call("list_available_toolsets", nil)
+			_, _ = batchRead.Handler(context.Background(), createMCPRequest(map[string]any{
+				"calls": []any{map[string]any{"tool": "list_gists"}},
+			}))
+		}
+	}()
+	wg.Wait()
+}
+
+func Test_ListAvailableToolsets_ExcludedTools(t *testing.T) {
+	s, tsg, dynamic := newDynamicTestServer(t, 0)
+	require.NoError(t, tsg.FilterTools(nil, []string{"list_*"}))
+
+	result := callDynamicTool(t, dynamic, "list_available_toolsets", nil)
+	var toolsetInfos []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &toolsetInfos))
+
+	byName := make(map[string]map[string]string)
+	for _, info := range toolsetInfos {
+		byName[info["name"]] = info
+	}
+	assert.Equal(t, "1", byName["issues"]["available_tools"])
+	assert.Equal(t, "list_issues", byName["issues"]["excluded_tools"])
+	assert.Equal(t, "false", byName["gists"]["can_enable"])
+
+	callDynamicTool(t, dynamic, "enable_toolset", map[string]any{"toolset": "issues"})
+	names := registeredToolNames(t, s)
+	assert.Contains(t, names, "get_issue")
+	assert.NotContains(t, names, "list_issues")
+}
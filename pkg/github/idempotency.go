@@ -0,0 +1,320 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// IdempotencyKeyTTL is how long the result of a call with an idempotency key is kept.
+	IdempotencyKeyTTL = 24 * time.Hour
+	// DuplicateWindow is how long an identical creation without an idempotency key is flagged as a possible duplicate.
+	DuplicateWindow = 10 * time.Minute
+)
+
+// idempotentTools are the create-style tools that accept an idempotency key.
+var idempotentTools = map[string]bool{
+	"create_issue":        true,
+	"create_pull_request": true,
+	"add_issue_comment":   true,
+	"create_gist":         true,
+}
+
+// idempotencyEntry is the stored outcome of a successful create call. Entries for duplicate
+// detection only record when the last identical creation succeeded.
+type idempotencyEntry struct {
+	Fingerprint string    `json:"fingerprint"`
+	Texts       []string  `json:"texts,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	pending     bool
+	// inFlight is the number of identical calls running for a duplicate detection entry
+	inFlight int
+}
+
+func (e idempotencyEntry) result() *mcp.CallToolResult {
+	result := &mcp.CallToolResult{}
+	for _, text := range e.Texts {
+		result.Content = append(result.Content, mcp.NewTextContent(text))
+	}
+	return result
+}
+
+// IdempotencyStore remembers the results of create calls so that retries return the original
+// result instead of creating duplicates. Entries are kept in memory and, when a path is given,
+// persisted to a file so that they survive restarts.
+type IdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+	path    string
+	now     func() time.Time
+}
+
+// NewIdempotencyStore creates a store, loading previously persisted entries from path if it is not empty.
+func NewIdempotencyStore(path string) (*IdempotencyStore, error) {
+	s := &IdempotencyStore{
+		entries: make(map[string]idempotencyEntry),
+		path:    path,
+		now:     time.Now,
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // the path is provided by the server operator
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read idempotency store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse idempotency store: %w", err)
+	}
+	s.pruneLocked()
+	return s, nil
+}
+
+// idempotencyState is the outcome of looking up an idempotency key.
+type idempotencyState int
+
+const (
+	idempotencyNew idempotencyState = iota
+	idempotencyReplay
+	idempotencyMismatch
+	idempotencyInProgress
+)
+
+// duplicateState describes identical calls made recently, regardless of their idempotency key.
+type duplicateState struct {
+	// inProgress is true when an identical call was running when the call began
+	inProgress bool
+	// createdAt is when an identical call last succeeded within the DuplicateWindow
+	createdAt time.Time
+}
+
+// begin looks up a key and, if it has not been seen, reserves it and the fingerprint of the call
+// in the same critical section, so that concurrent identical calls observe each other. An empty
+// key only reserves the fingerprint.
+func (s *IdempotencyStore) begin(key, fingerprint string) (idempotencyEntry, idempotencyState, duplicateState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+
+	if key != "" {
+		entry, ok := s.entries[key]
+		switch {
+		case !ok:
+			s.entries[key] = idempotencyEntry{Fingerprint: fingerprint, CreatedAt: s.now(), pending: true}
+		case entry.Fingerprint != fingerprint:
+			return entry, idempotencyMismatch, duplicateState{}
+		case entry.pending:
+			return entry, idempotencyInProgress, duplicateState{}
+		default:
+			return entry, idempotencyReplay, duplicateState{}
+		}
+	}
+
+	duplicate := s.entries[duplicateKey(fingerprint)]
+	state := duplicateState{inProgress: duplicate.inFlight > 0}
+	if !duplicate.CreatedAt.IsZero() && s.now().Sub(duplicate.CreatedAt) <= DuplicateWindow {
+		state.createdAt = duplicate.CreatedAt
+	}
+	duplicate.Fingerprint = fingerprint
+	duplicate.inFlight++
+	s.entries[duplicateKey(fingerprint)] = duplicate
+	return idempotencyEntry{}, idempotencyNew, state
+}
+
+// finish stores the result of a successful call for key, or releases the key if the call failed,
+// and releases the reservation of the fingerprint.
+func (s *IdempotencyStore) finish(key, fingerprint string, result *mcp.CallToolResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	succeeded := result != nil && !result.IsError
+
+	if key != "" {
+		if succeeded {
+			s.entries[key] = idempotencyEntry{Fingerprint: fingerprint, Texts: resultTexts(result), CreatedAt: s.now()}
+		} else {
+			delete(s.entries, key)
+		}
+	}
+
+	duplicate := s.entries[duplicateKey(fingerprint)]
+	duplicate.inFlight--
+	if succeeded {
+		duplicate.CreatedAt = s.now()
+	}
+	if duplicate.inFlight <= 0 && duplicate.CreatedAt.IsZero() {
+		delete(s.entries, duplicateKey(fingerprint))
+	} else {
+		s.entries[duplicateKey(fingerprint)] = duplicate
+	}
+
+	if succeeded {
+		s.persistLocked()
+	}
+}
+
+// pruneLocked removes results of keys older than the IdempotencyKeyTTL and duplicate detection
+// entries older than the DuplicateWindow.
+func (s *IdempotencyStore) pruneLocked() {
+	now := s.now()
+	for key, entry := range s.entries {
+		if entry.pending || entry.inFlight > 0 {
+			continue
+		}
+		ttl := IdempotencyKeyTTL
+		if strings.HasPrefix(key, duplicateKeyPrefix) {
+			ttl = DuplicateWindow
+		}
+		if now.Sub(entry.CreatedAt) > ttl {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// persistLocked writes the completed entries to the store file. Persistence is best effort,
+// a failure to write only loses protection across restarts.
+func (s *IdempotencyStore) persistLocked() {
+	if s.path == "" {
+		return
+	}
+	completed := make(map[string]idempotencyEntry, len(s.entries))
+	for key, entry := range s.entries {
+		if !entry.pending && !entry.CreatedAt.IsZero() {
+			completed[key] = entry
+		}
+	}
+	data, err := json.Marshal(completed)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func idempotencyKey(tool, key string) string {
+	return "key:" + tool + ":" + key
+}
+
+const duplicateKeyPrefix = "duplicate:"
+
+func duplicateKey(fingerprint string) string {
+	return duplicateKeyPrefix + fingerprint
+}
+
+// callFingerprint identifies a call by its tool and arguments, ignoring the idempotency key.
+func callFingerprint(tool string, arguments map[string]any) string {
+	args := make(map[string]any, len(arguments))
+	for k, v := range arguments {
+		if k != "idempotency_key" {
+			args[k] = v
+		}
+	}
+	// Maps are marshalled with sorted keys, so identical arguments produce identical JSON
+	data, _ := json.Marshal(args)
+	sum := sha256.Sum256(append([]byte(tool+"\x00"), data...))
+	return hex.EncodeToString(sum[:])
+}
+
+func resultTexts(result *mcp.CallToolResult) []string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return texts
+}
+
+// WithIdempotencyKey adds the shared "idempotency_key" parameter to a tool.
+func WithIdempotencyKey() mcp.ToolOption {
+	return mcp.WithString("idempotency_key",
+		mcp.Description("Optional unique key for this creation. Retrying with the same key and arguments returns the original result instead of creating a duplicate. Without a key, the call always creates, and a warning is added to the result when an identical creation was made within the last few minutes."),
+	)
+}
+
+// IdempotentTool returns a decorator that adds the "idempotency_key" parameter to create-style
+// tools and replays stored results for retried calls. Other tools are returned unchanged.
+func IdempotentTool(store *IdempotencyStore) func(server.ServerTool) server.ServerTool {
+	return func(st server.ServerTool) server.ServerTool {
+		if !idempotentTools[st.Tool.Name] {
+			return st
+		}
+		tool := st.Tool
+		tool.InputSchema.Properties = cloneProperties(tool.InputSchema.Properties)
+		WithIdempotencyKey()(&tool)
+
+		handler := st.Handler
+		return server.ServerTool{
+			Tool: tool,
+			Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				key, err := OptionalParam[string](request, "idempotency_key")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				fingerprint := callFingerprint(tool.Name, request.GetArguments())
+
+				var storeKey string
+				if key != "" {
+					storeKey = idempotencyKey(tool.Name, key)
+				}
+				entry, state, duplicate := store.begin(storeKey, fingerprint)
+				switch state {
+				case idempotencyReplay:
+					return entry.result(), nil
+				case idempotencyMismatch:
+					return mcp.NewToolResultError(fmt.Sprintf("idempotency_key %q was already used with different arguments", key)), nil
+				case idempotencyInProgress:
+					return mcp.NewToolResultError(fmt.Sprintf("a call with idempotency_key %q is still in progress, retry later", key)), nil
+				}
+
+				result, err := handler(ctx, request)
+				if err != nil {
+					store.finish(storeKey, fingerprint, nil)
+					return result, err
+				}
+				store.finish(storeKey, fingerprint, result)
+
+				// Without a key a repeated call may be intended, e.g. a second "+1" comment, so it is
+				// only flagged as a possible duplicate
+				if key == "" && result != nil && !result.IsError {
+					switch {
+					case duplicate.inProgress:
+						result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+							"Warning: an identical %s call was running at the same time, this may have created a duplicate. Provide an idempotency_key to make retries safe.",
+							tool.Name)))
+					case !duplicate.createdAt.IsZero():
+						result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+							"Warning: an identical %s call succeeded %s ago, this may have created a duplicate. Provide an idempotency_key to make retries safe.",
+							tool.Name, store.now().Sub(duplicate.createdAt).Round(time.Second))))
+					}
+				}
+				return result, nil
+			},
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCreateTool returns a create_issue stand-in that numbers the issues it creates.
+func countingCreateTool(calls *int) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_issue",
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(false)}),
+			mcp.WithString("title", mcp.Required()),
+		),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if title == "fail" {
+				return mcp.NewToolResultError("failed to create issue"), nil
+			}
+			*calls++
+			return mcp.NewToolResultText(fmt.Sprintf(`{"number":%d}`, *calls)), nil
+		},
+	}
+}
+
+func Test_IdempotentTool(t *testing.T) {
+	t.Run("only create tools are decorated", func(t *testing.T) {
+		listTool := server.ServerTool{Tool: mcp.NewTool("list_issues")}
+		store, _ := NewIdempotencyStore("")
+		assert.NotContains(t, IdempotentTool(store)(listTool).Tool.InputSchema.Properties, "idempotency_key")
+	})
+
+	t.Run("replays results for the same key", func(t *testing.T) {
+		var calls int
+		store, _ := NewIdempotencyStore("")
+		tool := IdempotentTool(store)(countingCreateTool(&calls))
+		assert.Contains(t, tool.Tool.InputSchema.Properties, "idempotency_key")
+
+		args := map[string]any{"title": "Bug", "idempotency_key": "abc"}
+		first, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		second, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, `{"number":1}`, getTextResult(t, first).Text)
+		assert.Equal(t, `{"number":1}`, getTextResult(t, second).Text)
+
+		// A new key creates a new issue, even with identical arguments
+		third, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"title": "Bug", "idempotency_key": "def"}))
+		require.NoError(t, err)
+		assert.Equal(t, `{"number":2}`, getTextResult(t, third).Text)
+	})
+
+	t.Run("rejects a key reused with different arguments", func(t *testing.T) {
+		var calls int
+		store, _ := NewIdempotencyStore("")
+		tool := IdempotentTool(store)(countingCreateTool(&calls))
+
+		_, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"title": "Bug", "idempotency_key": "abc"}))
+		require.NoError(t, err)
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"title": "Other", "idempotency_key": "abc"}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "already used with different arguments")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("failed calls can be retried with the same key", func(t *testing.T) {
+		var calls int
+		store, _ := NewIdempotencyStore("")
+		tool := IdempotentTool(store)(countingCreateTool(&calls))
+
+		args := map[string]any{"title": "fail", "idempotency_key": "abc"}
+		for i := 0; i < 2; i++ {
+			result, err := tool.Handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Equal(t, "failed to create issue", getErrorResult(t, result).Text)
+		}
+	})
+
+	t.Run("warns about recent identical creations without a key", func(t *testing.T) {
+		var calls int
+		store, _ := NewIdempotencyStore("")
+		now := time.Now()
+		store.now = func() time.Time { return now }
+		tool := IdempotentTool(store)(countingCreateTool(&calls))
+
+		args := map[string]any{"title": "Bug"}
+		first, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.Len(t, first.Content, 1)
+
+		// A repeated call without a key may be intended, so it creates again with a warning
+		now = now.Add(time.Minute)
+		second, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.Len(t, second.Content, 2)
+		assert.Equal(t, `{"number":2}`, second.Content[0].(mcp.TextContent).Text)
+		assert.Contains(t, second.Content[1].(mcp.TextContent).Text, "succeeded 1m0s ago")
+		assert.Equal(t, 2, calls)
+
+		now = now.Add(DuplicateWindow + time.Second)
+		third, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		assert.Len(t, third.Content, 1)
+		assert.Equal(t, 3, calls)
+
+		now = now.Add(DuplicateWindow + time.Second)
+		store.mu.Lock()
+		store.pruneLocked()
+		store.mu.Unlock()
+		assert.Empty(t, store.entries, "duplicate detection entries are pruned after the DuplicateWindow")
+	})
+
+	t.Run("concurrent calls with the same key create once", func(t *testing.T) {
+		var calls int
+		store, _ := NewIdempotencyStore("")
+		started := make(chan struct{})
+		release := make(chan struct{})
+		blocking := countingCreateTool(&calls)
+		create := blocking.Handler
+		blocking.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			close(started)
+			<-release
+			return create(ctx, request)
+		}
+		tool := IdempotentTool(store)(blocking)
+
+		args := map[string]any{"title": "Bug", "idempotency_key": "abc"}
+		done := make(chan *mcp.CallToolResult)
+		go func() {
+			result, _ := tool.Handler(context.Background(), createMCPRequest(args))
+			done <- result
+		}()
+		<-started
+
+		concurrent, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.True(t, concurrent.IsError)
+		assert.Contains(t, getErrorResult(t, concurrent).Text, "still in progress")
+
+		// An identical call without a key sees the running call
+		_, _, duplicate := store.begin("", callFingerprint("create_issue", args))
+		assert.True(t, duplicate.inProgress)
+		store.finish("", callFingerprint("create_issue", args), nil)
+
+		close(release)
+		first := <-done
+		assert.Equal(t, `{"number":1}`, getTextResult(t, first).Text)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("keys are persisted to the store file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "idempotency.json")
+		var calls int
+		store, err := NewIdempotencyStore(path)
+		require.NoError(t, err)
+		args := map[string]any{"title": "Bug", "idempotency_key": "abc"}
+		_, err = IdempotentTool(store)(countingCreateTool(&calls)).Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+
+		reloaded, err := NewIdempotencyStore(path)
+		require.NoError(t, err)
+		result, err := IdempotentTool(reloaded)(countingCreateTool(&calls)).Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		assert.Equal(t, `{"number":1}`, getTextResult(t, result).Text)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -194,7 +194,7 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get issue", resp, body), nil
 			}
 
 			r, err := json.Marshal(issue)
@@ -241,7 +241,7 @@ func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list issue types: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list issue types", resp, body), nil
 			}
 
 			r, err := json.Marshal(issueTypes)
@@ -315,7 +315,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create comment: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create comment", resp, body), nil
 			}
 
 			r, err := json.Marshal(createdComment)
@@ -403,7 +403,7 @@ func AddSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to add sub-issue: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to add sub-issue", resp, body), nil
 			}
 
 			r, err := json.Marshal(subIssue)
@@ -492,7 +492,7 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list sub-issues: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list sub-issues", resp, body), nil
 			}
 
 			r, err := json.Marshal(subIssues)
@@ -576,7 +576,7 @@ func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to remove sub-issue: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to remove sub-issue", resp, body), nil
 			}
 
 			r, err := json.Marshal(subIssue)
@@ -689,7 +689,7 @@ func ReprioritizeSubIssue(getClient GetClientFn, t translations.TranslationHelpe
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to reprioritize sub-issue: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to reprioritize sub-issue", resp, body), nil
 			}
 
 			r, err := json.Marshal(subIssue)
@@ -869,7 +869,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create issue: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create issue", resp, body), nil
 			}
 
 			// Return minimal response with just essential information
@@ -926,6 +926,7 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				mcp.Description("Filter by date (ISO 8601 timestamp)"),
 			),
 			WithCursorPagination(),
+			WithFetchAll(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1005,6 +1006,11 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError("This tool uses cursor-based pagination. Use the 'after' parameter with the 'endCursor' value from the previous response instead of 'page'."), nil
 			}
 
+			fetchAll, err := OptionalFetchAllParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			// Check if pagination parameters were explicitly provided
 			_, perPageProvided := request.GetArguments()["perPage"]
 			paginationExplicit := perPageProvided
@@ -1017,6 +1023,9 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			// Use default of 30 if pagination was not explicitly provided
 			if !paginationExplicit {
 				defaultFirst := int32(DefaultGraphQLPageSize)
+				if fetchAll.FetchAll {
+					defaultFirst = fetchAllPerPage
+				}
 				paginationParams.First = &defaultFirst
 			}
 
@@ -1025,68 +1034,86 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
 			}
 
-			vars := map[string]interface{}{
-				"owner":     githubv4.String(owner),
-				"repo":      githubv4.String(repo),
-				"states":    states,
-				"orderBy":   githubv4.IssueOrderField(orderBy),
-				"direction": githubv4.OrderDirection(direction),
-				"first":     githubv4.Int(*paginationParams.First),
+			// The page info of the last fetched page is reported so callers can continue from it
+			var lastPageInfo struct {
+				HasNextPage     githubv4.Boolean
+				HasPreviousPage githubv4.Boolean
+				StartCursor     githubv4.String
+				EndCursor       githubv4.String
 			}
+			var totalCount int
+			fetchPage := func(ctx context.Context, first int32, after *string) ([]*github.Issue, graphQLPageInfo, error) {
+				vars := map[string]interface{}{
+					"owner":     githubv4.String(owner),
+					"repo":      githubv4.String(repo),
+					"states":    states,
+					"orderBy":   githubv4.IssueOrderField(orderBy),
+					"direction": githubv4.OrderDirection(direction),
+					"first":     githubv4.Int(first),
+				}
 
-			if paginationParams.After != nil {
-				vars["after"] = githubv4.String(*paginationParams.After)
-			} else {
-				// Used within query, therefore must be set to nil and provided as $after
-				vars["after"] = (*githubv4.String)(nil)
-			}
+				if after != nil {
+					vars["after"] = githubv4.String(*after)
+				} else {
+					// Used within query, therefore must be set to nil and provided as $after
+					vars["after"] = (*githubv4.String)(nil)
+				}
 
-			// Ensure optional parameters are set
-			if hasLabels {
-				// Use query with labels filtering - convert string labels to githubv4.String slice
-				labelStrings := make([]githubv4.String, len(labels))
-				for i, label := range labels {
-					labelStrings[i] = githubv4.String(label)
+				// Ensure optional parameters are set
+				if hasLabels {
+					// Use query with labels filtering - convert string labels to githubv4.String slice
+					labelStrings := make([]githubv4.String, len(labels))
+					for i, label := range labels {
+						labelStrings[i] = githubv4.String(label)
+					}
+					vars["labels"] = labelStrings
 				}
-				vars["labels"] = labelStrings
-			}
 
-			if hasSince {
-				vars["since"] = githubv4.DateTime{Time: sinceTime}
-			}
+				if hasSince {
+					vars["since"] = githubv4.DateTime{Time: sinceTime}
+				}
 
-			issueQuery := getIssueQueryType(hasLabels, hasSince)
-			if err := client.Query(ctx, issueQuery, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				issueQuery := getIssueQueryType(hasLabels, hasSince)
+				if err := client.Query(ctx, issueQuery, vars); err != nil {
+					return nil, graphQLPageInfo{}, err
+				}
+
+				// Extract and convert all issue nodes using the common interface
+				var issues []*github.Issue
+				var pageInfo graphQLPageInfo
+				if queryResult, ok := issueQuery.(IssueQueryResult); ok {
+					fragment := queryResult.GetIssueFragment()
+					for _, issue := range fragment.Nodes {
+						issues = append(issues, fragmentToIssue(issue))
+					}
+					pageInfo = graphQLPageInfo{
+						HasNextPage: fragment.PageInfo.HasNextPage,
+						EndCursor:   fragment.PageInfo.EndCursor,
+					}
+					lastPageInfo = fragment.PageInfo
+					totalCount = fragment.TotalCount
+				}
+				return issues, pageInfo, nil
 			}
 
-			// Extract and convert all issue nodes using the common interface
 			var issues []*github.Issue
-			var pageInfo struct {
-				HasNextPage     githubv4.Boolean
-				HasPreviousPage githubv4.Boolean
-				StartCursor     githubv4.String
-				EndCursor       githubv4.String
+			if fetchAll.FetchAll {
+				issues, _, err = fetchGraphQLPages(ctx, *paginationParams.First, paginationParams.After, fetchAll.MaxItems, fetchPage)
+			} else {
+				issues, _, err = fetchPage(ctx, *paginationParams.First, paginationParams.After)
 			}
-			var totalCount int
-
-			if queryResult, ok := issueQuery.(IssueQueryResult); ok {
-				fragment := queryResult.GetIssueFragment()
-				for _, issue := range fragment.Nodes {
-					issues = append(issues, fragmentToIssue(issue))
-				}
-				pageInfo = fragment.PageInfo
-				totalCount = fragment.TotalCount
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list issues", err), nil
 			}
 
 			// Create response with issues
 			response := map[string]interface{}{
 				"issues": issues,
 				"pageInfo": map[string]interface{}{
-					"hasNextPage":     pageInfo.HasNextPage,
-					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
+					"hasNextPage":     lastPageInfo.HasNextPage,
+					"hasPreviousPage": lastPageInfo.HasPreviousPage,
+					"startCursor":     string(lastPageInfo.StartCursor),
+					"endCursor":       string(lastPageInfo.EndCursor),
 				},
 				"totalCount": totalCount,
 			}
@@ -1244,7 +1271,7 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update issue: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to update issue", resp, body), nil
 			}
 
 			// Return minimal response with just essential information
@@ -1323,7 +1350,7 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get issue comments", resp, body), nil
 			}
 
 			r, err := json.Marshal(comments)
@@ -1445,7 +1472,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 				var query suggestedActorsQuery
 				err := client.Query(ctx, &query, variables)
 				if err != nil {
-					return nil, err
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get suggested actors", err), nil
 				}
 
 				// Iterate all the returned nodes looking for the copilot bot, which is supposed to have the
@@ -1491,7 +1518,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 			}
 
 			if err := client.Query(ctx, &getIssueQuery, variables); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue ID: %v", err)), nil
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get issue ID", err), nil
 			}
 
 			// Finally, do the assignment. Just for reference, assigning copilot to an issue that it is already
@@ -11,6 +11,7 @@ import (
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -1030,6 +1031,35 @@ func Test_ListIssues(t *testing.T) {
 	}
 }
 
+func Test_ListIssues_RateLimited(t *testing.T) {
+	query := "query($after:String$direction:OrderDirection!$first:Int!$orderBy:IssueOrderField!$owner:String!$repo:String!$states:[IssueState!]!){repository(owner: $owner, name: $repo){issues(first: $first, after: $after, states: $states, orderBy: {field: $orderBy, direction: $direction}){nodes{number,title,body,state,databaseId,author{login},createdAt,updatedAt,labels(first: 100){nodes{name,id,description}},comments{totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	vars := map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"states":    []interface{}{"OPEN", "CLOSED"},
+		"orderBy":   "CREATED_AT",
+		"direction": "DESC",
+		"first":     float64(30),
+		"after":     (*string)(nil),
+	}
+	matcher := githubv4mock.NewQueryMatcher(query, vars, githubv4mock.ErrorResponse("API rate limit exceeded for user ID 1."))
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+	_, handler := ListIssues(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "failed to list issues")
+
+	info, ok := result.Meta[ghErrors.ErrorMetaKey].(ghErrors.ErrorInfo)
+	require.True(t, ok, "error result should carry an error classification")
+	assert.Equal(t, ghErrors.CategoryRateLimited, info.Category)
+	assert.True(t, info.Retryable)
+}
+
 func Test_UpdateIssue(t *testing.T) {
 	// Verify tool definition
 	mockClient := github.NewClient(nil)
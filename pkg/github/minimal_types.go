@@ -86,6 +86,28 @@ type MinimalCommit struct {
 	Files     []MinimalCommitFile `json:"files,omitempty"`
 }
 
+// MinimalComparisonFile represents a file changed between two refs.
+type MinimalComparisonFile struct {
+	MinimalCommitFile
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Patch            string `json:"patch,omitempty"`
+	// PatchOmitted is set when the patch was left out to stay within the patch budget
+	PatchOmitted bool `json:"patch_omitted,omitempty"`
+}
+
+// MinimalComparison is the trimmed output type for the comparison of two refs.
+type MinimalComparison struct {
+	Status          string                  `json:"status"`
+	AheadBy         int                     `json:"ahead_by"`
+	BehindBy        int                     `json:"behind_by"`
+	TotalCommits    int                     `json:"total_commits"`
+	MergeBaseCommit string                  `json:"merge_base_commit,omitempty"`
+	HTMLURL         string                  `json:"html_url,omitempty"`
+	Stats           MinimalCommitStats      `json:"stats"`
+	Commits         []MinimalCommit         `json:"commits"`
+	Files           []MinimalComparisonFile `json:"files"`
+}
+
 // MinimalRelease is the trimmed output type for release objects.
 type MinimalRelease struct {
 	ID          int64        `json:"id"`
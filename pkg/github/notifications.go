@@ -47,6 +47,7 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				mcp.Description("Optional repository name. If provided with owner, only notifications for this repository are listed."),
 			),
 			WithPagination(),
+			WithFetchAll(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -82,6 +83,11 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			fetchAll, err := OptionalFetchAllParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams.PerPage = fetchAll.pageSize(request, paginationParams.PerPage)
 
 			// Build options
 			opts := &github.NotificationListOptions{
@@ -113,10 +119,19 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 			var notifications []*github.Notification
 			var resp *github.Response
 
-			if owner != "" && repo != "" {
-				notifications, resp, err = client.Activity.ListRepositoryNotifications(ctx, owner, repo, opts)
+			listPage := func(ctx context.Context, page int) ([]*github.Notification, *github.Response, error) {
+				pageOpts := *opts
+				pageOpts.Page = page
+				if owner != "" && repo != "" {
+					return client.Activity.ListRepositoryNotifications(ctx, owner, repo, &pageOpts)
+				}
+				return client.Activity.ListNotifications(ctx, &pageOpts)
+			}
+
+			if fetchAll.FetchAll {
+				notifications, resp, err = fetchRESTPages(ctx, paginationParams.Page, paginationParams.PerPage, fetchAll.MaxItems, listPage)
 			} else {
-				notifications, resp, err = client.Activity.ListNotifications(ctx, opts)
+				notifications, resp, err = listPage(ctx, paginationParams.Page)
 			}
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -132,7 +147,7 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get notifications: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get notifications", resp, body), nil
 			}
 
 			// Marshal response to JSON
@@ -205,7 +220,7 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to mark notification as %s: %s", state, string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, fmt.Sprintf("failed to mark notification as %s", state), resp, body), nil
 			}
 
 			return mcp.NewToolResultText(fmt.Sprintf("Notification marked as %s", state)), nil
@@ -284,7 +299,7 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to mark all notifications as read: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to mark all notifications as read", resp, body), nil
 			}
 
 			return mcp.NewToolResultText("All notifications marked as read"), nil
@@ -330,7 +345,7 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get notification details: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get notification details", resp, body), nil
 			}
 
 			r, err := json.Marshal(thread)
@@ -412,7 +427,7 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 
 			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 				body, _ := io.ReadAll(resp.Body)
-				return mcp.NewToolResultError(fmt.Sprintf("failed to %s notification subscription: %s", action, string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, fmt.Sprintf("failed to %s notification subscription", action), resp, body), nil
 			}
 
 			if action == NotificationActionDelete {
@@ -508,7 +523,7 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 			// Handle non-2xx status codes
 			if resp != nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
 				body, _ := io.ReadAll(resp.Body)
-				return mcp.NewToolResultError(fmt.Sprintf("failed to %s repository subscription: %s", action, string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, fmt.Sprintf("failed to %s repository subscription", action), resp, body), nil
 			}
 
 			if action == RepositorySubscriptionActionDelete {
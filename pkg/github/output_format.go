@@ -0,0 +1,507 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OutputFormat controls how the JSON results of read tools are rendered.
+type OutputFormat string
+
+const (
+	// OutputFormatJSON returns results unchanged.
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatMarkdown renders lists as markdown tables and single objects as markdown summaries.
+	OutputFormatMarkdown OutputFormat = "markdown"
+	// OutputFormatCSV renders lists as CSV with a header row.
+	OutputFormatCSV OutputFormat = "csv"
+	// OutputFormatTable renders lists as compact, column aligned plain text tables.
+	OutputFormatTable OutputFormat = "table"
+)
+
+// OutputFormats lists the supported output formats.
+var OutputFormats = []string{
+	string(OutputFormatJSON),
+	string(OutputFormatMarkdown),
+	string(OutputFormatCSV),
+	string(OutputFormatTable),
+}
+
+// maxCellLength is the number of characters after which markdown and table cells are truncated.
+const maxCellLength = 100
+
+// identifierKeys are the fields, in order of preference, used to summarize a nested object in a single cell.
+var identifierKeys = []string{"login", "full_name", "name", "title", "tag_name", "ghsa_id", "number", "sha", "id"}
+
+// ParseOutputFormat validates an output format name. An empty name selects JSON.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	if s == "" {
+		return OutputFormatJSON, nil
+	}
+	for _, format := range OutputFormats {
+		if strings.EqualFold(s, format) {
+			return OutputFormat(format), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output format %q, must be one of: %s", s, strings.Join(OutputFormats, ", "))
+}
+
+// WithOutputFormat adds the shared "output_format" parameter to a tool.
+func WithOutputFormat(defaultFormat OutputFormat) mcp.ToolOption {
+	return mcp.WithString("output_format",
+		mcp.Description(fmt.Sprintf("Optional format of the result. 'markdown' renders lists as tables and objects as summaries, 'csv' renders lists as CSV and 'table' renders lists as compact text tables. Long text is truncated in markdown and table cells. Defaults to '%s'.", defaultFormat)),
+		mcp.Enum(OutputFormats...),
+	)
+}
+
+// OptionalOutputFormatParam returns the "output_format" parameter from the request, falling back
+// to the server default when it was not provided.
+func OptionalOutputFormatParam(r mcp.CallToolRequest, defaultFormat OutputFormat) (OutputFormat, error) {
+	format, err := OptionalParam[string](r, "output_format")
+	if err != nil {
+		return "", err
+	}
+	if format == "" {
+		return defaultFormat, nil
+	}
+	return ParseOutputFormat(format)
+}
+
+// OutputFormatTool returns a decorator that adds the "output_format" parameter to a read-only
+// tool and renders its JSON results in the requested format.
+func OutputFormatTool(defaultFormat OutputFormat) func(server.ServerTool) server.ServerTool {
+	return func(st server.ServerTool) server.ServerTool {
+		tool := st.Tool
+		if _, exists := tool.InputSchema.Properties["output_format"]; exists {
+			// The tool defines its own output_format parameter
+			return st
+		}
+		tool.InputSchema.Properties = cloneProperties(tool.InputSchema.Properties)
+		WithOutputFormat(defaultFormat)(&tool)
+
+		handler := st.Handler
+		return server.ServerTool{
+			Tool: tool,
+			Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				format, err := OptionalOutputFormatParam(request, defaultFormat)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				result, err := handler(ctx, request)
+				if err != nil || format == OutputFormatJSON {
+					return result, err
+				}
+
+				// API link fields are only dropped when the caller did not pick the fields explicitly
+				_, projected := request.GetArguments()["fields"]
+				formatted, err := formatToolResult(result, format, !projected)
+				if err != nil {
+					return mcp.NewToolResultErrorFromErr("failed to format result", err), nil
+				}
+				return formatted, nil
+			},
+		}
+	}
+}
+
+// formatToolResult renders every JSON text content of a successful result in the given format.
+// Text that is not JSON, such as diffs or status messages, is returned unchanged.
+func formatToolResult(result *mcp.CallToolResult, format OutputFormat, omitLinks bool) (*mcp.CallToolResult, error) {
+	if result == nil || result.IsError {
+		return result, nil
+	}
+	r := outputRenderer{omitLinks: omitLinks}
+	for i, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok || !json.Valid([]byte(text.Text)) {
+			continue
+		}
+
+		v, err := decodeOrdered([]byte(text.Text))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode result: %w", err)
+		}
+
+		rendered, err := r.render(v, format)
+		if err != nil {
+			return nil, err
+		}
+		text.Text = rendered
+		result.Content[i] = text
+	}
+	return result, nil
+}
+
+// orderedObject is a decoded JSON object that keeps the order of its keys, so that rendered
+// columns follow the order in which the API returned the fields.
+type orderedObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeOrdered decodes JSON into *orderedObject, []any and scalar values.
+func decodeOrdered(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decodeOrderedValue(decoder)
+}
+
+func decodeOrderedValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := &orderedObject{values: make(map[string]any)}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected object key %v", keyToken)
+			}
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := obj.values[key]; !exists {
+				obj.keys = append(obj.keys, key)
+			}
+			obj.values[key] = value
+		}
+		// Consume the closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case json.Delim('['):
+		arr := []any{}
+		for decoder.More() {
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return arr, nil
+	default:
+		return token, nil
+	}
+}
+
+type outputRenderer struct {
+	// omitLinks drops API link fields such as "comments_url", which make up a large share of
+	// REST responses but rarely help when reading tabular output.
+	omitLinks bool
+}
+
+func (r outputRenderer) render(v any, format OutputFormat) (string, error) {
+	switch format {
+	case OutputFormatMarkdown:
+		return r.markdown(v), nil
+	case OutputFormatCSV:
+		return r.csv(v)
+	case OutputFormatTable:
+		return r.table(v), nil
+	default:
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
+
+func (r outputRenderer) omit(key string) bool {
+	return r.omitLinks && (key == "node_id" || (strings.HasSuffix(key, "_url") && key != "html_url"))
+}
+
+// list returns the rows to render for v. Besides plain arrays, an object with exactly one array
+// field is treated as a list wrapped with metadata, like {"issues": [...], "pageInfo": {...}},
+// in which case the remaining fields are returned as metadata.
+func (r outputRenderer) list(v any) (rows []any, metadata *orderedObject, isList bool) {
+	switch value := v.(type) {
+	case []any:
+		return value, nil, true
+	case *orderedObject:
+		listKey := ""
+		for _, key := range value.keys {
+			if _, ok := value.values[key].([]any); ok && !r.omit(key) {
+				if listKey != "" {
+					return nil, nil, false
+				}
+				listKey = key
+			}
+		}
+		if listKey == "" {
+			return nil, nil, false
+		}
+		metadata = &orderedObject{values: value.values}
+		for _, key := range value.keys {
+			if key != listKey {
+				metadata.keys = append(metadata.keys, key)
+			}
+		}
+		return value.values[listKey].([]any), metadata, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// columns returns the union of the fields of all object rows, in order of first appearance.
+func (r outputRenderer) columns(rows []any) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		obj, ok := row.(*orderedObject)
+		if !ok {
+			continue
+		}
+		for _, key := range obj.keys {
+			if !seen[key] && !r.omit(key) {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return []string{"value"}
+	}
+	return columns
+}
+
+func rowCells(row any, columns []string, cell func(any) string) []string {
+	obj, ok := row.(*orderedObject)
+	if !ok {
+		return []string{cell(row)}
+	}
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		cells[i] = cell(obj.values[column])
+	}
+	return cells
+}
+
+func (r outputRenderer) markdown(v any) string {
+	rows, metadata, isList := r.list(v)
+	if !isList {
+		if obj, ok := v.(*orderedObject); ok {
+			return r.markdownObject(obj)
+		}
+		return inlineValue(v)
+	}
+	if metadata != nil && len(metadata.keys) > 0 {
+		return r.markdownObject(metadata) + "\n\n" + r.markdownTable(rows)
+	}
+	return r.markdownTable(rows)
+}
+
+func (r outputRenderer) markdownObject(obj *orderedObject) string {
+	var b strings.Builder
+	var sections []string
+	for _, key := range obj.keys {
+		if r.omit(key) {
+			continue
+		}
+		value := obj.values[key]
+		switch typed := value.(type) {
+		case []any:
+			if containsObjects(typed) {
+				sections = append(sections, fmt.Sprintf("### %s\n\n%s", key, r.markdownTable(typed)))
+				continue
+			}
+		case string:
+			if strings.Contains(typed, "\n") {
+				sections = append(sections, fmt.Sprintf("### %s\n\n%s", key, strings.TrimSpace(typed)))
+				continue
+			}
+		}
+		fmt.Fprintf(&b, "- **%s**: %s\n", key, inlineValue(value))
+	}
+	for _, section := range sections {
+		b.WriteString("\n" + section + "\n")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func (r outputRenderer) markdownTable(rows []any) string {
+	if len(rows) == 0 {
+		return "_No results._"
+	}
+	columns := r.columns(rows)
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + strings.ReplaceAll(cell, "|", `\|`) + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(columns)
+	b.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, row := range rows {
+		writeRow(rowCells(row, columns, tableCell))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (r outputRenderer) csv(v any) (string, error) {
+	rows, metadata, isList := r.list(v)
+	if !isList {
+		rows = []any{v}
+	}
+
+	var b strings.Builder
+	r.writeMetadata(&b, metadata)
+	w := csv.NewWriter(&b)
+	columns := r.columns(rows)
+	if err := w.Write(columns); err != nil {
+		return "", err
+	}
+	for _, row := range rows {
+		if err := w.Write(rowCells(row, columns, inlineValue)); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return b.String(), w.Error()
+}
+
+func (r outputRenderer) table(v any) string {
+	rows, metadata, isList := r.list(v)
+
+	var b strings.Builder
+	r.writeMetadata(&b, metadata)
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	switch {
+	case isList:
+		columns := r.columns(rows)
+		fmt.Fprintln(tw, strings.Join(columns, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(rowCells(row, columns, tableCell), "\t"))
+		}
+	default:
+		obj, ok := v.(*orderedObject)
+		if !ok {
+			return inlineValue(v)
+		}
+		// A single object is rendered as one field per line
+		for _, key := range obj.keys {
+			if !r.omit(key) {
+				fmt.Fprintf(tw, "%s\t%s\n", key, tableCell(obj.values[key]))
+			}
+		}
+	}
+	_ = tw.Flush()
+
+	// Empty trailing cells leave padding behind
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeMetadata writes the fields accompanying a list, such as total counts and page info, as comment lines.
+func (r outputRenderer) writeMetadata(b *strings.Builder, metadata *orderedObject) {
+	if metadata == nil {
+		return
+	}
+	for _, key := range metadata.keys {
+		if !r.omit(key) {
+			fmt.Fprintf(b, "# %s: %s\n", key, inlineValue(metadata.values[key]))
+		}
+	}
+}
+
+func containsObjects(values []any) bool {
+	for _, value := range values {
+		if _, ok := value.(*orderedObject); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// inlineValue renders a value as a single string. Nested objects are summarized by their
+// identifying field, e.g. a user by its login, and arrays are joined with commas.
+func inlineValue(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	case []any:
+		parts := make([]string, len(value))
+		for i, elem := range value {
+			parts[i] = inlineValue(elem)
+		}
+		return strings.Join(parts, ", ")
+	case *orderedObject:
+		for _, key := range identifierKeys {
+			switch id := value.values[key].(type) {
+			case string, json.Number:
+				return inlineValue(id)
+			}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// tableCell renders a value on a single line, truncated to maxCellLength characters.
+func tableCell(v any) string {
+	s := strings.Join(strings.Fields(inlineValue(v)), " ")
+	if utf8.RuneCountInString(s) <= maxCellLength {
+		return s
+	}
+	return string([]rune(s)[:maxCellLength-1]) + "…"
+}
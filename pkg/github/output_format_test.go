@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseOutputFormat(t *testing.T) {
+	format, err := ParseOutputFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, OutputFormatJSON, format)
+
+	format, err = ParseOutputFormat("Markdown")
+	require.NoError(t, err)
+	assert.Equal(t, OutputFormatMarkdown, format)
+
+	_, err = ParseOutputFormat("xml")
+	require.Error(t, err)
+}
+
+func Test_RenderOutput(t *testing.T) {
+	issues := `[
+		{"number": 1, "title": "First | pipes", "user": {"login": "octocat", "id": 1}, "labels": [{"name": "bug"}, {"name": "p1"}], "comments_url": "https://api.github.com/x"},
+		{"number": 2, "title": "Second", "user": null, "labels": []}
+	]`
+
+	tests := []struct {
+		name      string
+		input     string
+		format    OutputFormat
+		omitLinks bool
+		expected  string
+	}{
+		{
+			name:      "markdown table for a list",
+			input:     issues,
+			format:    OutputFormatMarkdown,
+			omitLinks: true,
+			expected: "| number | title | user | labels |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| 1 | First \\| pipes | octocat | bug, p1 |\n" +
+				"| 2 | Second |  |  |",
+		},
+		{
+			name:   "csv for a list keeps link fields when requested",
+			input:  issues,
+			format: OutputFormatCSV,
+			expected: "number,title,user,labels,comments_url\n" +
+				"1,First | pipes,octocat,\"bug, p1\",https://api.github.com/x\n" +
+				"2,Second,,,\n",
+		},
+		{
+			name:      "compact table for a list",
+			input:     issues,
+			format:    OutputFormatTable,
+			omitLinks: true,
+			expected: "number  title          user     labels\n" +
+				"1       First | pipes  octocat  bug, p1\n" +
+				"2       Second",
+		},
+		{
+			name:      "metadata of wrapped lists is kept",
+			input:     `{"issues": [{"number": 1}], "totalCount": 1}`,
+			format:    OutputFormatCSV,
+			omitLinks: true,
+			expected:  "# totalCount: 1\nnumber\n1\n",
+		},
+		{
+			name:      "markdown summary for a single object",
+			input:     `{"number": 1, "state": "open", "user": {"login": "octocat"}, "body": "line one\nline two", "html_url": "https://github.com/o/r/issues/1", "url": "https://api.github.com/repos/o/r/issues/1", "events_url": "https://api.github.com/x"}`,
+			format:    OutputFormatMarkdown,
+			omitLinks: true,
+			expected: "- **number**: 1\n" +
+				"- **state**: open\n" +
+				"- **user**: octocat\n" +
+				"- **html_url**: https://github.com/o/r/issues/1\n" +
+				"- **url**: https://api.github.com/repos/o/r/issues/1\n" +
+				"\n### body\n\nline one\nline two",
+		},
+		{
+			name:      "empty list",
+			input:     `[]`,
+			format:    OutputFormatMarkdown,
+			omitLinks: true,
+			expected:  "_No results._",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := decodeOrdered([]byte(tc.input))
+			require.NoError(t, err)
+
+			r := outputRenderer{omitLinks: tc.omitLinks}
+			actual, err := r.render(v, tc.format)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_OutputFormatTool(t *testing.T) {
+	original := server.ServerTool{
+		Tool: mcp.NewTool("stub_tool",
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)}),
+		),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if _, ok := request.GetArguments()["plain"]; ok {
+				return mcp.NewToolResultText("not json"), nil
+			}
+			return MarshalledTextResult([]map[string]any{{"number": 1}, {"number": 2}}), nil
+		},
+	}
+
+	formatted := OutputFormatTool(OutputFormatCSV)(original)
+	assert.Contains(t, formatted.Tool.InputSchema.Properties, "output_format")
+	assert.NotContains(t, original.Tool.InputSchema.Properties, "output_format")
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "uses the server default",
+			args:     map[string]any{},
+			expected: "number\n1\n2\n",
+		},
+		{
+			name:     "per-call override",
+			args:     map[string]any{"output_format": "json"},
+			expected: `[{"number":1},{"number":2}]`,
+		},
+		{
+			name:     "non JSON results are unchanged",
+			args:     map[string]any{"plain": true},
+			expected: "not json",
+		},
+		{
+			name:        "invalid format",
+			args:        map[string]any{"output_format": "xml"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := formatted.Handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				return
+			}
+			assert.Equal(t, tc.expected, getTextResult(t, result).Text)
+		})
+	}
+}
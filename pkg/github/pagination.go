@@ -0,0 +1,199 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// DefaultMaxFetchItems is the number of items returned by fetch_all when max_items is not provided.
+	DefaultMaxFetchItems = 500
+	// MaxFetchItems is the upper bound for max_items.
+	MaxFetchItems = 5000
+	// fetchAllPerPage is the page size used when fetching all pages and no perPage was requested.
+	fetchAllPerPage = 100
+	// maxConcurrentPageFetches limits the number of REST pages fetched in parallel.
+	maxConcurrentPageFetches = 4
+)
+
+// pageSize returns the page size to request. When fetching all pages and no "perPage" was
+// provided, the largest page size is used to keep the number of round-trips down.
+func (p FetchAllParams) pageSize(r mcp.CallToolRequest, perPage int) int {
+	if _, ok := r.GetArguments()["perPage"]; !ok && p.FetchAll {
+		return fetchAllPerPage
+	}
+	return perPage
+}
+
+// restPageFetcher fetches a single page of a REST list endpoint.
+type restPageFetcher[T any] func(ctx context.Context, page int) ([]T, *github.Response, error)
+
+// fetchRESTPages fetches pages of a REST list endpoint starting at startPage until there are no
+// more pages or maxItems have been collected.
+//
+// When the first response carries a "last" link the remaining pages are known up front and are
+// fetched concurrently, otherwise the "next" link is followed page by page. On failure the
+// response of the failing request is returned alongside the error.
+func fetchRESTPages[T any](ctx context.Context, startPage, perPage, maxItems int, fetch restPageFetcher[T]) ([]T, *github.Response, error) {
+	if startPage < 1 {
+		startPage = 1
+	}
+
+	items, resp, err := fetch(ctx, startPage)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	if len(items) >= maxItems || resp.NextPage == 0 {
+		return truncateItems(items, maxItems), resp, nil
+	}
+
+	if resp.LastPage > 0 && perPage > 0 {
+		lastPage := resp.LastPage
+		neededPages := (maxItems + perPage - 1) / perPage
+		if capPage := startPage + neededPages - 1; capPage < lastPage {
+			lastPage = capPage
+		}
+		totalPages := float64(lastPage - startPage + 1)
+		ReportProgress(ctx, 1, totalPages, fmt.Sprintf("Fetched page 1 of %d", int(totalPages)))
+		rest, failedResp, err := fetchRESTPageRange(ctx, resp.NextPage, lastPage, fetch)
+		if err != nil {
+			return nil, failedResp, err
+		}
+		for _, page := range rest {
+			items = append(items, page...)
+		}
+		return truncateItems(items, maxItems), resp, nil
+	}
+
+	for fetched := 1; resp.NextPage != 0 && len(items) < maxItems; fetched++ {
+		ReportProgress(ctx, float64(fetched), 0, fmt.Sprintf("Fetched %d items", len(items)))
+		var pageItems []T
+		pageItems, resp, err = fetch(ctx, resp.NextPage)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		items = append(items, pageItems...)
+	}
+
+	return truncateItems(items, maxItems), resp, nil
+}
+
+// fetchRESTPageRange fetches pages first through last (inclusive) concurrently and returns them in page order.
+// Progress is reported as pages complete, counting the page before first as already fetched.
+func fetchRESTPageRange[T any](ctx context.Context, first, last int, fetch restPageFetcher[T]) ([][]T, *github.Response, error) {
+	if last < first {
+		return nil, nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]T, last-first+1)
+	sem := make(chan struct{}, maxConcurrentPageFetches)
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		firstErr   error
+		failedResp *github.Response
+		fetched    = 1
+	)
+	totalPages := last - first + 2
+
+	for page := first; page <= last; page++ {
+		wg.Add(1)
+		go func(page int) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			items, resp, err := fetch(ctx, page)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					failedResp = resp
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			_ = resp.Body.Close()
+			pages[page-first] = items
+
+			mu.Lock()
+			fetched++
+			// Reported under the lock, so that progress increases
+			ReportProgress(ctx, float64(fetched), float64(totalPages), fmt.Sprintf("Fetched page %d of %d", fetched, totalPages))
+			mu.Unlock()
+		}(page)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, failedResp, firstErr
+	}
+	return pages, nil, nil
+}
+
+// graphQLPageInfo is the subset of a GraphQL PageInfo needed to follow cursors.
+type graphQLPageInfo struct {
+	HasNextPage githubv4.Boolean
+	EndCursor   githubv4.String
+}
+
+// graphQLPageFetcher fetches up to first items of a GraphQL connection after the given cursor.
+type graphQLPageFetcher[T any] func(ctx context.Context, first int32, after *string) ([]T, graphQLPageInfo, error)
+
+// fetchGraphQLPages follows the endCursor of a GraphQL connection until there are no more pages
+// or maxItems have been collected. Cursors are opaque, so pages are fetched sequentially.
+// The last page is shrunk to the remaining item budget, so the returned page info can be used
+// to resume exactly where the results stop.
+func fetchGraphQLPages[T any](ctx context.Context, perPage int32, after *string, maxItems int, fetch graphQLPageFetcher[T]) ([]T, graphQLPageInfo, error) {
+	var items []T
+	var pageInfo graphQLPageInfo
+
+	for {
+		first := perPage
+		if remaining := maxItems - len(items); remaining < int(first) {
+			first = int32(remaining) //nolint:gosec // remaining is bounded by perPage
+		}
+		pageItems, info, err := fetch(ctx, first, after)
+		if err != nil {
+			return nil, pageInfo, err
+		}
+		pageInfo = info
+		items = append(items, pageItems...)
+		if len(pageItems) > 0 {
+			ReportProgress(ctx, float64(len(items)), float64(maxItems), fmt.Sprintf("Fetched %d items", len(items)))
+		}
+
+		if !bool(pageInfo.HasNextPage) || len(items) >= maxItems || len(pageItems) == 0 {
+			break
+		}
+		cursor := string(pageInfo.EndCursor)
+		after = &cursor
+	}
+
+	return truncateItems(items, maxItems), pageInfo, nil
+}
+
+func truncateItems[T any](items []T, maxItems int) []T {
+	if maxItems > 0 && len(items) > maxItems {
+		return items[:maxItems]
+	}
+	return items
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePagedResponse builds a github.Response for the given page of a result set with
+// totalPages pages, optionally advertising the last page like a "last" Link header does.
+func fakePagedResponse(page, totalPages int, withLastLink bool) *github.Response {
+	resp := &github.Response{
+		Response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("")),
+		},
+	}
+	if page < totalPages {
+		resp.NextPage = page + 1
+		if withLastLink {
+			resp.LastPage = totalPages
+		}
+	}
+	return resp
+}
+
+func fakePageItems(page, perPage int) []int {
+	items := make([]int, perPage)
+	for i := range items {
+		items[i] = (page-1)*perPage + i
+	}
+	return items
+}
+
+func Test_FetchRESTPages(t *testing.T) {
+	tests := []struct {
+		name          string
+		startPage     int
+		perPage       int
+		totalPages    int
+		maxItems      int
+		withLastLink  bool
+		expectedCount int
+		expectedPages []int
+	}{
+		{
+			name:          "follows next links until exhausted",
+			startPage:     1,
+			perPage:       10,
+			totalPages:    3,
+			maxItems:      100,
+			expectedCount: 30,
+			expectedPages: []int{1, 2, 3},
+		},
+		{
+			name:          "fetches known pages concurrently",
+			startPage:     1,
+			perPage:       10,
+			totalPages:    5,
+			maxItems:      100,
+			withLastLink:  true,
+			expectedCount: 50,
+			expectedPages: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:          "stops at max items when following next links",
+			startPage:     1,
+			perPage:       10,
+			totalPages:    10,
+			maxItems:      25,
+			expectedCount: 25,
+			expectedPages: []int{1, 2, 3},
+		},
+		{
+			name:          "does not fetch pages beyond max items when last page is known",
+			startPage:     1,
+			perPage:       10,
+			totalPages:    10,
+			maxItems:      25,
+			withLastLink:  true,
+			expectedCount: 25,
+			expectedPages: []int{1, 2, 3},
+		},
+		{
+			name:          "starts at requested page",
+			startPage:     2,
+			perPage:       10,
+			totalPages:    3,
+			maxItems:      100,
+			withLastLink:  true,
+			expectedCount: 20,
+			expectedPages: []int{2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var fetched []int
+			fetch := func(_ context.Context, page int) ([]int, *github.Response, error) {
+				mu.Lock()
+				fetched = append(fetched, page)
+				mu.Unlock()
+				return fakePageItems(page, tc.perPage), fakePagedResponse(page, tc.totalPages, tc.withLastLink), nil
+			}
+
+			items, _, err := fetchRESTPages(context.Background(), tc.startPage, tc.perPage, tc.maxItems, fetch)
+			require.NoError(t, err)
+			assert.Len(t, items, tc.expectedCount)
+			assert.ElementsMatch(t, tc.expectedPages, fetched)
+
+			// Items must be returned in page order regardless of fetch order
+			for i := 1; i < len(items); i++ {
+				assert.Equal(t, items[i-1]+1, items[i])
+			}
+		})
+	}
+
+	t.Run("returns the failing response", func(t *testing.T) {
+		failing := &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}
+		fetch := func(_ context.Context, page int) ([]int, *github.Response, error) {
+			if page == 3 {
+				return nil, failing, errors.New("forbidden")
+			}
+			return fakePageItems(page, 10), fakePagedResponse(page, 5, true), nil
+		}
+
+		items, resp, err := fetchRESTPages(context.Background(), 1, 10, 100, fetch)
+		require.Error(t, err)
+		assert.Nil(t, items)
+		assert.Equal(t, failing, resp)
+	})
+}
+
+func Test_FetchGraphQLPages(t *testing.T) {
+	const totalItems = 25
+
+	fetch := func(_ context.Context, first int32, after *string) ([]int, graphQLPageInfo, error) {
+		start := 0
+		if after != nil {
+			start = len(*after)
+		}
+		end := start + int(first)
+		if end > totalItems {
+			end = totalItems
+		}
+		var items []int
+		for i := start; i < end; i++ {
+			items = append(items, i)
+		}
+		return items, graphQLPageInfo{
+			HasNextPage: githubv4.Boolean(end < totalItems),
+			EndCursor:   githubv4.String(strings.Repeat("x", end)),
+		}, nil
+	}
+
+	t.Run("follows cursors until exhausted", func(t *testing.T) {
+		items, pageInfo, err := fetchGraphQLPages(context.Background(), 10, nil, 100, fetch)
+		require.NoError(t, err)
+		assert.Len(t, items, totalItems)
+		assert.False(t, bool(pageInfo.HasNextPage))
+	})
+
+	t.Run("shrinks the last page to max items", func(t *testing.T) {
+		items, pageInfo, err := fetchGraphQLPages(context.Background(), 10, nil, 15, fetch)
+		require.NoError(t, err)
+		assert.Len(t, items, 15)
+		assert.True(t, bool(pageInfo.HasNextPage))
+		assert.Equal(t, strings.Repeat("x", 15), string(pageInfo.EndCursor))
+	})
+
+	t.Run("starts after the given cursor", func(t *testing.T) {
+		after := strings.Repeat("x", 20)
+		items, _, err := fetchGraphQLPages(context.Background(), 10, &after, 100, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, []int{20, 21, 22, 23, 24}, items)
+	})
+}
@@ -0,0 +1,617 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultPatchFuzz is the number of context lines a hunk may ignore when fuzz is not provided.
+	DefaultPatchFuzz = 2
+	// MaxPatchFuzz is the upper bound for fuzz.
+	MaxPatchFuzz = 3
+)
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// patchHunk is a hunk of a unified diff.
+type patchHunk struct {
+	header   string
+	oldStart int
+	// lines keep their " ", "-" or "+" prefix
+	lines []string
+	// oldNoEOL and newNoEOL are set by "\ No newline at end of file" markers
+	oldNoEOL bool
+	newNoEOL bool
+}
+
+// oldLines returns the lines the hunk expects in the file.
+func (h patchHunk) oldLines() []string {
+	var lines []string
+	for _, line := range h.lines {
+		if line[0] != '+' {
+			lines = append(lines, line[1:])
+		}
+	}
+	return lines
+}
+
+// newLines returns the lines the hunk replaces them with.
+func (h patchHunk) newLines() []string {
+	var lines []string
+	for _, line := range h.lines {
+		if line[0] != '-' {
+			lines = append(lines, line[1:])
+		}
+	}
+	return lines
+}
+
+// filePatch is the part of a unified diff that changes a single file.
+type filePatch struct {
+	oldPath string
+	newPath string
+	// newMode is the mode of the file after the patch, from git's "new mode" headers
+	newMode string
+	hunks   []patchHunk
+}
+
+func (p *filePatch) isNew() bool    { return p.oldPath == "" }
+func (p *filePatch) isDelete() bool { return p.newPath == "" }
+
+// patchPath strips the timestamp and the a/ or b/ prefix of a path in a ---, +++ or diff --git
+// header. An empty path is returned for /dev/null.
+func patchPath(header, prefix string) string {
+	p, _, _ := strings.Cut(header, "\t")
+	p = strings.TrimSpace(p)
+	if p == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(p, prefix)
+}
+
+// isHunkLine reports whether a line may belong to the body of a hunk. Empty lines are accepted
+// as context lines whose trailing space was stripped.
+func isHunkLine(lines []string, i int) bool {
+	line := lines[i]
+	if line == "" {
+		return true
+	}
+	switch line[0] {
+	case ' ', '+', '\\':
+		return true
+	case '-':
+		// A "---" line followed by "+++" starts the next file
+		return !(strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "))
+	}
+	return false
+}
+
+// parsePatch parses a unified diff of one or more files, as produced by diff -u or git diff.
+// Line counts of hunk headers are not trusted, since hand-written diffs often get them wrong.
+func parsePatch(diff string) ([]*filePatch, error) {
+	lines := strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n")
+	var files []*filePatch
+	var current *filePatch
+	// gitHeader is set between a diff --git line and the ---/+++ lines of the same file
+	gitHeader := false
+	startFile := func() {
+		current = &filePatch{}
+		files = append(files, current)
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			startFile()
+			gitHeader = true
+			// Paths of renames and mode changes without hunks are only in this header
+			if oldPath, newPath, ok := strings.Cut(strings.TrimPrefix(line, "diff --git "), " b/"); ok {
+				current.oldPath = patchPath(oldPath, "a/")
+				current.newPath = newPath
+			}
+		case current != nil && strings.HasPrefix(line, "rename from "):
+			current.oldPath = strings.TrimPrefix(line, "rename from ")
+		case current != nil && strings.HasPrefix(line, "rename to "):
+			current.newPath = strings.TrimPrefix(line, "rename to ")
+		case current != nil && strings.HasPrefix(line, "new file mode "):
+			current.oldPath = ""
+			current.newMode = strings.TrimPrefix(line, "new file mode ")
+		case current != nil && strings.HasPrefix(line, "new mode "):
+			current.newMode = strings.TrimPrefix(line, "new mode ")
+		case current != nil && strings.HasPrefix(line, "deleted file mode "):
+			current.newPath = ""
+		case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+			// Binary changes carry no hunks and would otherwise be reported as applied unchanged
+			// Without a diff --git header the line does not belong to the current file
+			if !gitHeader {
+				return nil, fmt.Errorf("binary diffs cannot be applied: %q", line)
+			}
+			path := current.newPath
+			if path == "" {
+				path = current.oldPath
+			}
+			return nil, fmt.Errorf("%s is a binary diff, binary files cannot be patched", path)
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			oldPath := patchPath(strings.TrimPrefix(line, "--- "), "a/")
+			newPath := patchPath(strings.TrimPrefix(lines[i+1], "+++ "), "b/")
+			// Diffs without git headers start a new file at every ---, as do paths that do not
+			// belong to the preceding diff --git header
+			if !gitHeader || (oldPath != "" && oldPath != current.oldPath) || (newPath != "" && newPath != current.newPath) {
+				startFile()
+			}
+			gitHeader = false
+			current.oldPath, current.newPath = oldPath, newPath
+			i++
+		case strings.HasPrefix(line, "@@"):
+			if current == nil {
+				return nil, fmt.Errorf("hunk %q comes before any file header", line)
+			}
+			gitHeader = false
+			m := hunkHeaderRE.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("invalid hunk header %q", line)
+			}
+			hunk := patchHunk{header: m[0]}
+			hunk.oldStart, _ = strconv.Atoi(m[1])
+			for i+1 < len(lines) && isHunkLine(lines, i+1) {
+				i++
+				body := lines[i]
+				switch {
+				case body == "":
+					hunk.lines = append(hunk.lines, " ")
+				case body[0] == '\\':
+					if len(hunk.lines) > 0 {
+						switch hunk.lines[len(hunk.lines)-1][0] {
+						case '-':
+							hunk.oldNoEOL = true
+						case '+':
+							hunk.newNoEOL = true
+						default:
+							hunk.oldNoEOL, hunk.newNoEOL = true, true
+						}
+					}
+				default:
+					hunk.lines = append(hunk.lines, body)
+				}
+			}
+			// Trailing empty lines are the end of the diff rather than context
+			for len(hunk.lines) > 0 && hunk.lines[len(hunk.lines)-1] == " " {
+				hunk.lines = hunk.lines[:len(hunk.lines)-1]
+			}
+			current.hunks = append(current.hunks, hunk)
+		}
+	}
+
+	// Each path may only be patched once, since later changes would be applied to the original
+	// content rather than on top of the earlier ones
+	patched := make(map[string]bool)
+	for _, file := range files {
+		if file.oldPath == "" && file.newPath == "" {
+			return nil, fmt.Errorf("file header without paths")
+		}
+		paths := []string{file.oldPath}
+		if file.newPath != file.oldPath {
+			paths = append(paths, file.newPath)
+		}
+		for _, p := range paths {
+			if p == "" {
+				continue
+			}
+			if patched[p] {
+				return nil, fmt.Errorf("%s is patched more than once, combine its changes into a single file diff", p)
+			}
+			patched[p] = true
+		}
+	}
+	return files, nil
+}
+
+// linesMatch reports whether the lines of a file at pos match the expected lines. Trailing
+// whitespace is ignored.
+func linesMatch(lines []string, pos int, expected []string) bool {
+	if pos < 0 || pos+len(expected) > len(lines) {
+		return false
+	}
+	for i, line := range expected {
+		if strings.TrimRight(lines[pos+i], " \t\r") != strings.TrimRight(line, " \t\r") {
+			return false
+		}
+	}
+	return true
+}
+
+// findHunk returns the position closest to expected, at or after from, where the lines match.
+func findHunk(lines []string, expected []string, from, want int) int {
+	if len(expected) == 0 {
+		return max(from, min(want, len(lines)))
+	}
+	for distance := 0; ; distance++ {
+		before, after := want-distance, want+distance
+		if before < from && after+len(expected) > len(lines) {
+			return -1
+		}
+		if before >= from && linesMatch(lines, before, expected) {
+			return before
+		}
+		if distance > 0 && after >= from && linesMatch(lines, after, expected) {
+			return after
+		}
+	}
+}
+
+// contextTrim returns how many leading and trailing lines of the hunk are context lines, up to fuzz.
+func contextTrim(hunk patchHunk, fuzz int) (leading, trailing int) {
+	for leading < fuzz && leading < len(hunk.lines) && hunk.lines[leading][0] == ' ' {
+		leading++
+	}
+	for trailing < fuzz && trailing < len(hunk.lines)-leading && hunk.lines[len(hunk.lines)-1-trailing][0] == ' ' {
+		trailing++
+	}
+	return leading, trailing
+}
+
+// PatchedFile is a file changed by apply_patch.
+type PatchedFile struct {
+	Path         string `json:"path"`
+	PreviousPath string `json:"previous_path,omitempty"`
+	// Status is "added", "modified", "deleted" or "renamed"
+	Status        string `json:"status"`
+	HunksApplied  int    `json:"hunks_applied"`
+	HunksRejected int    `json:"hunks_rejected"`
+	// Notes tell which hunks were applied at an offset or with fuzz
+	Notes []string `json:"notes,omitempty"`
+}
+
+// RejectedHunk is a hunk that did not match the content of the file.
+type RejectedHunk struct {
+	Path   string `json:"path"`
+	Header string `json:"header"`
+	Reason string `json:"reason"`
+	// Expected are the lines the hunk looked for
+	Expected []string `json:"expected,omitempty"`
+}
+
+// ApplyPatchResult is the result of apply_patch.
+type ApplyPatchResult struct {
+	Commit   *CommitChangesResult `json:"commit,omitempty"`
+	Files    []PatchedFile        `json:"files"`
+	Rejected []RejectedHunk       `json:"rejected,omitempty"`
+}
+
+// applyHunks applies the hunks of a file patch to content. Hunks are looked for near the line
+// their header names, shifted by the offset of the previous hunk, and may ignore up to fuzz
+// context lines at their start and end. Hunks that do not match are rejected.
+func applyHunks(content string, p *filePatch, fuzz int) (string, PatchedFile, []RejectedHunk) {
+	result := PatchedFile{Path: p.newPath}
+	var rejected []RejectedHunk
+
+	eol := "\n"
+	if strings.Contains(content, "\r\n") {
+		eol = "\r\n"
+	}
+	var lines []string
+	finalEOL := true
+	if content != "" {
+		finalEOL = strings.HasSuffix(content, eol)
+		lines = strings.Split(strings.TrimSuffix(content, eol), eol)
+	}
+
+	// from keeps hunks in order, offset and delta track where the next hunk is expected
+	from, offset, delta := 0, 0, 0
+	for n, hunk := range p.hunks {
+		want := max(hunk.oldStart-1, 0) + delta + offset
+		pos, leading, trailing := -1, 0, 0
+		for f := 0; f <= fuzz && pos < 0; f++ {
+			leading, trailing = contextTrim(hunk, f)
+			if f > 0 && leading+trailing == 0 {
+				break
+			}
+			oldLines := hunk.oldLines()
+			pos = findHunk(lines, oldLines[leading:len(oldLines)-trailing], from, want+leading)
+			if pos >= 0 && f > 0 {
+				result.Notes = append(result.Notes, fmt.Sprintf("hunk %d applied with fuzz %d", n+1, f))
+			}
+		}
+		if pos < 0 {
+			rejected = append(rejected, RejectedHunk{
+				Path:     p.newPath,
+				Header:   hunk.header,
+				Reason:   "the lines of the hunk were not found in the file",
+				Expected: hunk.oldLines(),
+			})
+			result.HunksRejected++
+			continue
+		}
+		if shift := pos - leading - max(hunk.oldStart-1, 0) - delta; shift != offset {
+			offset = shift
+			if offset != 0 {
+				result.Notes = append(result.Notes, fmt.Sprintf("hunk %d applied at offset %+d", n+1, offset))
+			}
+		}
+
+		oldLines, newLines := hunk.oldLines(), hunk.newLines()
+		removed := len(oldLines) - leading - trailing
+		added := newLines[leading : len(newLines)-trailing]
+		atEnd := pos+removed == len(lines)
+		lines = append(lines[:pos], append(append([]string{}, added...), lines[pos+removed:]...)...)
+		from = pos + len(added)
+		delta += len(added) - removed
+		if atEnd && trailing == 0 {
+			switch {
+			case hunk.newNoEOL:
+				finalEOL = false
+			case hunk.oldNoEOL:
+				finalEOL = true
+			}
+		}
+		result.HunksApplied++
+	}
+
+	out := strings.Join(lines, eol)
+	if finalEOL && len(lines) > 0 {
+		out += eol
+	}
+	return out, result, rejected
+}
+
+// ApplyPatch creates a tool to apply a unified diff to the files of a branch and commit the result.
+func ApplyPatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_patch",
+			mcp.WithDescription(t("TOOL_APPLY_PATCH_DESCRIPTION", "Apply a unified diff of one or more files, as produced by git diff, to a branch and commit the result. Hunks are matched with offset and fuzz handling, so that line numbers do not need to be exact. Prefer this over create_or_update_file to edit parts of large files")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_APPLY_PATCH_USER_TITLE", "Apply patch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to apply the patch to and commit to"),
+			),
+			mcp.WithString("patch",
+				mcp.Required(),
+				mcp.Description("Unified diff to apply. New, deleted and renamed files are supported"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithNumber("fuzz",
+				mcp.Description(fmt.Sprintf("Number of context lines at the start and end of a hunk that may not match (default %d, max %d)", DefaultPatchFuzz, MaxPatchFuzz)),
+				mcp.Min(0),
+				mcp.Max(MaxPatchFuzz),
+			),
+			mcp.WithBoolean("allow_partial",
+				mcp.Description("Commit the hunks that could be applied even if others were rejected. By default nothing is committed when a hunk is rejected"),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Only check whether the patch applies, without committing"),
+			),
+			mcp.WithString("expected_parent_sha",
+				mcp.Description("SHA the branch is expected to point to. The patch is rejected if the branch has moved"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			diff, err := RequiredParam[string](request, "patch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fuzz, err := OptionalIntParamWithDefault(request, "fuzz", DefaultPatchFuzz)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if fuzz < 0 || fuzz > MaxPatchFuzz {
+				return mcp.NewToolResultError(fmt.Sprintf("fuzz must be between 0 and %d", MaxPatchFuzz)), nil
+			}
+			allowPartial, err := OptionalParam[bool](request, "allow_partial")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun, err := OptionalParam[bool](request, "dry_run")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedParentSHA, err := OptionalParam[string](request, "expected_parent_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			patches, err := parsePatch(diff)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid patch: %s", err)), nil
+			}
+			if len(patches) == 0 {
+				return mcp.NewToolResultError("invalid patch: no file headers found"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get branch reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			parentSHA := ref.GetObject().GetSHA()
+			if expectedParentSHA != "" && parentSHA != expectedParentSHA {
+				return mcp.NewToolResultError(fmt.Sprintf("branch %s has moved to %s, expected %s. Read the files again and retry", branch, parentSHA, expectedParentSHA)), nil
+			}
+
+			parent, resp, err := client.Git.GetCommit(ctx, owner, repo, parentSHA)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get parent commit",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			baseTreeSHA := parent.GetTree().GetSHA()
+
+			trees := newTreeReader(client, owner, repo)
+			result := ApplyPatchResult{Files: []PatchedFile{}}
+			var entries []*github.TreeEntry
+			for _, p := range patches {
+				var existing *github.TreeEntry
+				content := ""
+				if !p.isNew() {
+					entry, resp, err := trees.entry(ctx, baseTreeSHA, p.oldPath)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get tree", resp, err), nil
+					}
+					if entry == nil || entry.GetType() != "blob" {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot patch %s, the file does not exist on %s", p.oldPath, branch)), nil
+					}
+					existing = entry
+
+					if !p.isDelete() && p.newPath != p.oldPath {
+						target, resp, err := trees.entry(ctx, baseTreeSHA, p.newPath)
+						if err != nil {
+							return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get tree", resp, err), nil
+						}
+						if target != nil {
+							return mcp.NewToolResultError(fmt.Sprintf("cannot rename %s to %s, the file already exists on %s", p.oldPath, p.newPath, branch)), nil
+						}
+					}
+				} else {
+					entry, resp, err := trees.entry(ctx, baseTreeSHA, p.newPath)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get tree", resp, err), nil
+					}
+					if entry != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot create %s, the file already exists on %s", p.newPath, branch)), nil
+					}
+				}
+
+				if p.isDelete() {
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(p.oldPath),
+						Mode: github.Ptr(existing.GetMode()),
+						Type: github.Ptr("blob"),
+					})
+					result.Files = append(result.Files, PatchedFile{Path: p.oldPath, Status: "deleted"})
+					continue
+				}
+
+				if existing != nil && len(p.hunks) > 0 {
+					raw, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, existing.GetSHA())
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to get content of %s", p.oldPath),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					content = string(raw)
+				}
+
+				patched, file, rejected := applyHunks(content, p, fuzz)
+				result.Rejected = append(result.Rejected, rejected...)
+				switch {
+				case p.isNew():
+					file.Status = "added"
+				case p.oldPath != p.newPath:
+					file.Status = "renamed"
+					file.PreviousPath = p.oldPath
+				default:
+					file.Status = "modified"
+				}
+				result.Files = append(result.Files, file)
+
+				mode := p.newMode
+				if mode == "" {
+					mode = fileMode(nil, existing)
+				}
+				entry := &github.TreeEntry{
+					Path: github.Ptr(p.newPath),
+					Mode: github.Ptr(mode),
+					Type: github.Ptr("blob"),
+				}
+				if file.HunksApplied == 0 && existing != nil {
+					// Renames and mode changes keep the content
+					entry.SHA = existing.SHA
+				} else {
+					entry.Content = github.Ptr(patched)
+				}
+				if file.Status == "renamed" {
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(p.oldPath),
+						Mode: github.Ptr(existing.GetMode()),
+						Type: github.Ptr("blob"),
+					})
+				}
+				entries = append(entries, entry)
+			}
+
+			if len(result.Rejected) > 0 && !allowPartial {
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("%d hunks could not be applied, nothing was committed: %s", len(result.Rejected), r)), nil
+			}
+
+			if !dryRun {
+				commit, errResult := commitToBranch(ctx, client, owner, repo, ref, baseTreeSHA, message, entries)
+				if errResult != nil {
+					return errResult, nil
+				}
+				result.Commit = &CommitChangesResult{
+					SHA:       commit.GetSHA(),
+					ParentSHA: parentSHA,
+					Branch:    branch,
+					HTMLURL:   commit.GetHTMLURL(),
+					Changes:   len(result.Files),
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,408 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const patchTestFile = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+}
+
+func helper() int {
+	return 1
+}
+`
+
+func Test_parsePatch(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -5,3 +5,3 @@ func main() {
+ func main() {
+-	fmt.Println("hello")
++	fmt.Println("hello, world")
+ }
+diff --git a/old.txt b/new.txt
+similarity index 100%
+rename from old.txt
+rename to new.txt
+diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+--- /dev/null	2024-01-01 00:00:00
++++ b/added.txt	2024-01-01 00:00:00
+@@ -0,0 +1,2 @@
++first
++second
+\ No newline at end of file
+`
+	files, err := parsePatch(diff)
+	require.NoError(t, err)
+	require.Len(t, files, 5)
+
+	assert.Equal(t, "main.go", files[0].oldPath)
+	assert.Equal(t, "main.go", files[0].newPath)
+	require.Len(t, files[0].hunks, 1)
+	assert.Equal(t, 5, files[0].hunks[0].oldStart)
+	assert.Equal(t, []string{"func main() {", "\tfmt.Println(\"hello\")", "}"}, files[0].hunks[0].oldLines())
+	assert.Equal(t, []string{"func main() {", "\tfmt.Println(\"hello, world\")", "}"}, files[0].hunks[0].newLines())
+
+	assert.Equal(t, "old.txt", files[1].oldPath)
+	assert.Equal(t, "new.txt", files[1].newPath)
+	assert.Empty(t, files[1].hunks)
+
+	assert.Equal(t, "run.sh", files[2].newPath)
+	assert.Equal(t, "100755", files[2].newMode)
+
+	assert.True(t, files[3].isDelete())
+	assert.Equal(t, "gone.txt", files[3].oldPath)
+
+	assert.True(t, files[4].isNew())
+	assert.Equal(t, "added.txt", files[4].newPath)
+	assert.True(t, files[4].hunks[0].newNoEOL)
+
+	_, err = parsePatch("@@ -1 +1 @@\n-a\n+b\n")
+	assert.ErrorContains(t, err, "comes before any file header")
+
+	_, err = parsePatch("--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-a\n+b\n--- a/main.go\n+++ b/main.go\n@@ -3 +3 @@\n-c\n+d\n")
+	assert.ErrorContains(t, err, "main.go is patched more than once")
+
+	_, err = parsePatch("diff --git a/old.txt b/main.go\nrename from old.txt\nrename to main.go\n--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-a\n+b\n")
+	assert.ErrorContains(t, err, "main.go is patched more than once")
+
+	_, err = parsePatch("diff --git a/logo.png b/logo.png\nindex 1111111..2222222 100644\nBinary files a/logo.png and b/logo.png differ\n")
+	assert.ErrorContains(t, err, "logo.png is a binary diff")
+
+	_, err = parsePatch("diff --git a/logo.png b/logo.png\nindex 1111111..2222222 100644\nGIT binary patch\nliteral 5\nMcmZ?wbhPvI0\n")
+	assert.ErrorContains(t, err, "logo.png is a binary diff")
+
+	_, err = parsePatch("Binary files a/logo.png and b/logo.png differ\n")
+	assert.ErrorContains(t, err, "binary diffs cannot be applied")
+}
+
+func Test_applyHunks(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		diff             string
+		fuzz             int
+		expected         string
+		expectedRejected int
+		expectedNotes    []string
+	}{
+		{
+			name:    "exact match",
+			content: patchTestFile,
+			diff: `--- a/main.go
++++ b/main.go
+@@ -5,3 +5,3 @@
+ func main() {
+-	fmt.Println("hello")
++	fmt.Println("hello, world")
+ }
+`,
+			expected: strings.Replace(patchTestFile, `"hello"`, `"hello, world"`, 1),
+		},
+		{
+			name:    "wrong line numbers are found at an offset",
+			content: patchTestFile,
+			diff: `--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ func helper() int {
+-	return 1
++	return 2
+ }
+`,
+			expected:      strings.Replace(patchTestFile, "return 1", "return 2", 1),
+			expectedNotes: []string{"hunk 1 applied at offset +8"},
+		},
+		{
+			name:    "stale context is accepted with fuzz",
+			content: patchTestFile,
+			diff: `--- a/main.go
++++ b/main.go
+@@ -9,3 +9,3 @@
+ func helperOld() int {
+-	return 1
++	return 2
+ }
+`,
+			fuzz:          1,
+			expected:      strings.Replace(patchTestFile, "return 1", "return 2", 1),
+			expectedNotes: []string{"hunk 1 applied with fuzz 1"},
+		},
+		{
+			name:    "stale context is rejected without fuzz",
+			content: patchTestFile,
+			diff: `--- a/main.go
++++ b/main.go
+@@ -9,3 +9,3 @@
+ func helperOld() int {
+-	return 1
++	return 2
+ }
+`,
+			expected:         patchTestFile,
+			expectedRejected: 1,
+		},
+		{
+			name:    "later hunks apply when an earlier one is rejected",
+			content: patchTestFile,
+			diff: `--- a/main.go
++++ b/main.go
+@@ -3,1 +3,1 @@
+-import "os"
++import "io"
+@@ -10,1 +10,2 @@
+ 	return 1
++	// unreachable
+`,
+			expected:         strings.Replace(patchTestFile, "\treturn 1\n", "\treturn 1\n\t// unreachable\n", 1),
+			expectedRejected: 1,
+		},
+		{
+			name:    "removes the final newline",
+			content: "a\nb\n",
+			diff: `--- a/f
++++ b/f
+@@ -1,2 +1,2 @@
+ a
+-b
++c
+\ No newline at end of file
+`,
+			expected: "a\nc",
+		},
+		{
+			name:    "keeps CRLF line endings",
+			content: "a\r\nb\r\n",
+			diff: `--- a/f
++++ b/f
+@@ -1,2 +1,3 @@
+ a
++x
+ b
+`,
+			expected: "a\r\nx\r\nb\r\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := parsePatch(tc.diff)
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+
+			out, file, rejected := applyHunks(tc.content, files[0], tc.fuzz)
+			assert.Equal(t, tc.expected, out)
+			assert.Len(t, rejected, tc.expectedRejected)
+			assert.Equal(t, tc.expectedRejected, file.HunksRejected)
+			assert.Equal(t, tc.expectedNotes, file.Notes)
+		})
+	}
+}
+
+func Test_ApplyPatch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApplyPatch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_patch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "fuzz")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_partial")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "patch", "message"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("parent-sha")},
+	}
+	mockParent := &github.Commit{
+		SHA:  github.Ptr("parent-sha"),
+		Tree: &github.Tree{SHA: github.Ptr("base-tree-sha")},
+	}
+	mockTree := &github.Tree{SHA: github.Ptr("base-tree-sha"), Entries: []*github.TreeEntry{
+		{Path: github.Ptr("main.go"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("main-sha")},
+		{Path: github.Ptr("run.sh"), Mode: github.Ptr("100755"), Type: github.Ptr("blob"), SHA: github.Ptr("run-sha")},
+	}}
+
+	editMain := `--- a/main.go
++++ b/main.go
+@@ -6,1 +6,1 @@
+-	fmt.Println("hello")
++	fmt.Println("hello, world")
+`
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectCommit   bool
+		expectedTree   []map[string]any
+		expectedFiles  []PatchedFile
+		expectedErrMsg string
+	}{
+		{
+			name: "edits, renames and creates files in one commit",
+			requestArgs: map[string]any{
+				"patch": editMain + `diff --git a/run.sh b/scripts/run.sh
+similarity index 100%
+rename from run.sh
+rename to scripts/run.sh
+--- /dev/null
++++ b/NOTES.md
+@@ -0,0 +1 @@
++notes
+`,
+			},
+			expectCommit: true,
+			expectedTree: []map[string]any{
+				{"path": "main.go", "mode": "100644", "type": "blob", "content": strings.Replace(patchTestFile, `"hello"`, `"hello, world"`, 1)},
+				{"path": "run.sh", "mode": "100755", "type": "blob", "sha": nil},
+				{"path": "scripts/run.sh", "mode": "100755", "type": "blob", "sha": "run-sha"},
+				{"path": "NOTES.md", "mode": "100644", "type": "blob", "content": "notes\n"},
+			},
+			expectedFiles: []PatchedFile{
+				{Path: "main.go", Status: "modified", HunksApplied: 1},
+				{Path: "scripts/run.sh", PreviousPath: "run.sh", Status: "renamed"},
+				{Path: "NOTES.md", Status: "added", HunksApplied: 1},
+			},
+		},
+		{
+			name:          "dry run does not commit",
+			requestArgs:   map[string]any{"patch": editMain, "dry_run": true},
+			expectedFiles: []PatchedFile{{Path: "main.go", Status: "modified", HunksApplied: 1}},
+		},
+		{
+			name: "rejected hunks commit nothing",
+			requestArgs: map[string]any{"patch": `--- a/main.go
++++ b/main.go
+@@ -1,1 +1,1 @@
+-package lib
++package app
+`},
+			expectedErrMsg: "1 hunks could not be applied, nothing was committed",
+		},
+		{
+			name: "rejected hunks are skipped with allow_partial",
+			requestArgs: map[string]any{"allow_partial": true, "patch": editMain + `@@ -1,1 +1,1 @@
+-package lib
++package app
+`},
+			expectCommit: true,
+			expectedTree: []map[string]any{
+				{"path": "main.go", "mode": "100644", "type": "blob", "content": strings.Replace(patchTestFile, `"hello"`, `"hello, world"`, 1)},
+			},
+			expectedFiles: []PatchedFile{{Path: "main.go", Status: "modified", HunksApplied: 1, HunksRejected: 1}},
+		},
+		{
+			name: "patch of a missing file",
+			requestArgs: map[string]any{"patch": `--- a/missing.go
++++ b/missing.go
+@@ -1 +1 @@
+-a
++b
+`},
+			expectedErrMsg: "cannot patch missing.go, the file does not exist on main",
+		},
+		{
+			name: "rename onto an existing file",
+			requestArgs: map[string]any{"patch": `diff --git a/run.sh b/main.go
+similarity index 100%
+rename from run.sh
+rename to main.go
+`},
+			expectedErrMsg: "cannot rename run.sh to main.go, the file already exists on main",
+		},
+		{
+			name:           "not a diff",
+			requestArgs:    map[string]any{"patch": "hello"},
+			expectedErrMsg: "invalid patch: no file headers found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var createdTree []map[string]any
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, mockParent),
+				mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, mockTree),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					expectPath(t, "/repos/owner/repo/git/blobs/main-sha").andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							_, _ = w.Write([]byte(patchTestFile))
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body struct {
+							Tree []map[string]any `json:"tree"`
+						}
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						createdTree = body.Tree
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write(mock.MustMarshal(&github.Tree{SHA: github.Ptr("new-tree-sha")}))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr("new-sha")}),
+				),
+				mock.WithRequestMatch(mock.PatchReposGitRefsByOwnerByRepoByRef, mockRef),
+			))
+			_, handler := ApplyPatch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "branch": "main", "message": "Apply patch"}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var applied ApplyPatchResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &applied))
+			assert.Equal(t, tc.expectedFiles, applied.Files)
+			assert.Equal(t, tc.expectedTree, createdTree)
+			if tc.expectCommit {
+				require.NotNil(t, applied.Commit)
+				assert.Equal(t, "new-sha", applied.Commit.SHA)
+			} else {
+				assert.Nil(t, applied.Commit)
+			}
+		})
+	}
+}
@@ -55,6 +55,24 @@ func GetTagWithPermissionCheck(getClient GetClientFn, t translations.Translation
 	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
 }
 
+// CompareRefsWithPermissionCheck creates a tool to compare refs with permission checking
+func CompareRefsWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := CompareRefs(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// GetFileBlameWithPermissionCheck creates a tool to get the blame of a file with permission checking
+func GetFileBlameWithPermissionCheck(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := GetFileBlame(getGQLClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// GetRepositoryTreeWithPermissionCheck creates a tool to get the repository tree with permission checking
+func GetRepositoryTreeWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := GetRepositoryTree(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
 // ListReleasesWithPermissionCheck creates a tool to list releases with permission checking
 func ListReleasesWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	originalTool, originalHandler := ListReleases(getClient, t)
@@ -67,12 +85,54 @@ func GetLatestReleaseWithPermissionCheck(getClient GetClientFn, t translations.T
 	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
 }
 
+// CreateTagWithPermissionCheck creates a tool to create tag with permission checking
+func CreateTagWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := CreateTag(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// DeleteTagWithPermissionCheck creates a tool to delete tag with permission checking
+func DeleteTagWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := DeleteTag(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
 // GetReleaseByTagWithPermissionCheck creates a tool to get release by tag with permission checking
 func GetReleaseByTagWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	originalTool, originalHandler := GetReleaseByTag(getClient, t)
 	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
 }
 
+// GenerateReleaseNotesWithPermissionCheck creates a tool to generate release notes with permission checking
+func GenerateReleaseNotesWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := GenerateReleaseNotes(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// CreateReleaseWithPermissionCheck creates a tool to create release with permission checking
+func CreateReleaseWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := CreateRelease(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// UpdateReleaseWithPermissionCheck creates a tool to update release with permission checking
+func UpdateReleaseWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := UpdateRelease(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// DeleteReleaseWithPermissionCheck creates a tool to delete release with permission checking
+func DeleteReleaseWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := DeleteRelease(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// UploadReleaseAssetWithPermissionCheck creates a tool to upload release asset with permission checking
+func UploadReleaseAssetWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := UploadReleaseAsset(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
 // ForkRepositoryWithPermissionCheck creates a tool to fork repository with permission checking
 func ForkRepositoryWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	originalTool, originalHandler := ForkRepository(getClient, t)
@@ -85,6 +145,12 @@ func RenameRepositoryWithPermissionCheck(getClient GetClientFn, t translations.T
 	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
 }
 
+// UpdateRepositoryWithPermissionCheck creates a tool to update repository settings with permission checking
+func UpdateRepositoryWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := UpdateRepository(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
 // CreateBranchWithPermissionCheck creates a tool to create branch with permission checking
 func CreateBranchWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	originalTool, originalHandler := CreateBranch(getClient, t)
@@ -97,8 +163,80 @@ func PushFilesWithPermissionCheck(getClient GetClientFn, t translations.Translat
 	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
 }
 
+// CommitChangesWithPermissionCheck creates a tool to commit changes to files with permission checking
+func CommitChangesWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := CommitChanges(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// ApplyPatchWithPermissionCheck creates a tool to apply a patch with permission checking
+func ApplyPatchWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := ApplyPatch(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
 // DeleteFileWithPermissionCheck creates a tool to delete file with permission checking
 func DeleteFileWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	originalTool, originalHandler := DeleteFile(getClient, t)
 	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
-}
\ No newline at end of file
+}
+
+// GetBranchProtectionWithPermissionCheck creates a tool to get branch protection with permission checking
+func GetBranchProtectionWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := GetBranchProtection(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// GetBranchRulesWithPermissionCheck creates a tool to get rules for a branch with permission checking
+func GetBranchRulesWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := GetBranchRules(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// ListRulesetsWithPermissionCheck creates a tool to list rulesets with permission checking
+func ListRulesetsWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := ListRulesets(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// GetRulesetWithPermissionCheck creates a tool to get ruleset with permission checking
+func GetRulesetWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := GetRuleset(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// CreateRulesetWithPermissionCheck creates a tool to create ruleset with permission checking
+func CreateRulesetWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := CreateRuleset(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// UpdateRulesetWithPermissionCheck creates a tool to update ruleset with permission checking
+func UpdateRulesetWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := UpdateRuleset(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// ListRepositoryCollaboratorsWithPermissionCheck creates a tool to list repository collaborators with permission checking
+func ListRepositoryCollaboratorsWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := ListRepositoryCollaborators(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// AddCollaboratorWithPermissionCheck creates a tool to add collaborator with permission checking
+func AddCollaboratorWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := AddCollaborator(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// RemoveCollaboratorWithPermissionCheck creates a tool to remove collaborator with permission checking
+func RemoveCollaboratorWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := RemoveCollaborator(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
+
+// SetTeamRepositoryPermissionWithPermissionCheck creates a tool to set team repository permission with permission checking
+func SetTeamRepositoryPermissionWithPermissionCheck(getClient GetClientFn, t translations.TranslationHelperFunc, repoChecker *RepoPermissionChecker) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	originalTool, originalHandler := SetTeamRepositoryPermission(getClient, t)
+	return originalTool, CreatePermissionCheckedTool(originalTool, originalHandler, repoChecker)
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requestIDMetaKey carries the JSON-RPC ID of a tool call from the before hook to the middleware
+// of the CallTracker, as mcp-go does not pass it to tool handlers.
+const requestIDMetaKey = "github-mcp-server/requestId"
+
+// CallTracker makes tool calls cancellable with notifications/cancelled and lets their handlers
+// report progress with ReportProgress. BeforeCallTool, Middleware and HandleCancelled must all be
+// registered with the server.
+type CallTracker struct {
+	mu    sync.Mutex
+	calls map[string]context.CancelFunc
+}
+
+// NewCallTracker creates a CallTracker without tool calls in flight.
+func NewCallTracker() *CallTracker {
+	return &CallTracker{calls: make(map[string]context.CancelFunc)}
+}
+
+// callKey identifies a request across sessions.
+func callKey(ctx context.Context, id any) string {
+	return fmt.Sprintf("%s/%v", sessionID(ctx), id)
+}
+
+// BeforeCallTool is a hook that records the ID of the request in the metadata of the tool call.
+func (t *CallTracker) BeforeCallTool(_ context.Context, id any, request *mcp.CallToolRequest) {
+	if request.Params.Meta == nil {
+		request.Params.Meta = &mcp.Meta{}
+	}
+	if request.Params.Meta.AdditionalFields == nil {
+		request.Params.Meta.AdditionalFields = make(map[string]any)
+	}
+	request.Params.Meta.AdditionalFields[requestIDMetaKey] = id
+}
+
+// Middleware runs tool calls with a context that is cancelled when the client cancels the request,
+// which aborts the outstanding requests to GitHub, and that carries the progress token of the call.
+func (t *CallTracker) Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if meta := request.Params.Meta; meta != nil {
+			if meta.ProgressToken != nil {
+				ctx = context.WithValue(ctx, progressTokenKey{}, meta.ProgressToken)
+			}
+			if id, ok := meta.AdditionalFields[requestIDMetaKey]; ok {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				key := callKey(ctx, id)
+				t.mu.Lock()
+				t.calls[key] = cancel
+				t.mu.Unlock()
+				defer func() {
+					t.mu.Lock()
+					delete(t.calls, key)
+					t.mu.Unlock()
+					cancel()
+				}()
+			}
+		}
+		return next(ctx, request)
+	}
+}
+
+// HandleCancelled is the handler of notifications/cancelled. It cancels the tool call with the
+// given request ID, if it is still running.
+func (t *CallTracker) HandleCancelled(ctx context.Context, notification mcp.JSONRPCNotification) {
+	id, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	cancel, ok := t.calls[callKey(ctx, id)]
+	t.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+type progressTokenKey struct{}
+
+// ReportProgress sends a progress notification for the tool call of ctx, if the client asked for
+// progress by passing a progress token. Progress must increase with every call. A total of 0
+// means that the total is unknown.
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	token, ok := ctx.Value(progressTokenKey{}).(mcp.ProgressToken)
+	if !ok || token == nil {
+		return
+	}
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return
+	}
+	params := map[string]any{
+		"progressToken": token,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	// Progress is best effort, the client may have gone away
+	_ = s.SendNotificationToClient(ctx, "notifications/progress", params)
+}
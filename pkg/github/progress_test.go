@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is an initialized client session that collects its notifications.
+type testSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testSession) SessionID() string                                   { return "test" }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+
+// newCallTrackerServer returns a server with the CallTracker registered and a context of an
+// initialized session.
+func newCallTrackerServer(t *testing.T, tools ...server.ServerTool) (*server.MCPServer, context.Context, *testSession) {
+	t.Helper()
+	calls := NewCallTracker()
+	s := server.NewMCPServer("test", "1",
+		server.WithToolCapabilities(true),
+		server.WithHooks(&server.Hooks{OnBeforeCallTool: []server.OnBeforeCallToolFunc{calls.BeforeCallTool}}),
+		server.WithToolHandlerMiddleware(calls.Middleware),
+	)
+	s.AddNotificationHandler("notifications/cancelled", calls.HandleCancelled)
+	s.AddTools(tools...)
+
+	session := &testSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+	return s, s.WithContext(context.Background(), session), session
+}
+
+func Test_CallTracker_Cancellation(t *testing.T) {
+	started := make(chan struct{})
+	blocking := server.ServerTool{
+		Tool: mcp.NewTool("wait"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			close(started)
+			<-ctx.Done()
+			return mcp.NewToolResultError(ctx.Err().Error()), nil
+		},
+	}
+	s, ctx, _ := newCallTrackerServer(t, blocking)
+
+	done := make(chan mcp.JSONRPCMessage, 1)
+	go func() {
+		done <- s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"wait"}}`))
+	}()
+	<-started
+
+	// Cancelling another request has no effect
+	s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":8}}`))
+	select {
+	case <-done:
+		t.Fatal("tool call returned before it was cancelled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user cancelled"}}`))
+	select {
+	case message := <-done:
+		response, ok := message.(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		result, ok := response.Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		assert.True(t, result.IsError)
+		assert.Equal(t, "context canceled", getErrorResult(t, &result).Text)
+	case <-time.After(time.Second):
+		t.Fatal("tool call was not cancelled")
+	}
+}
+
+func Test_ReportProgress(t *testing.T) {
+	reporting := server.ServerTool{
+		Tool: mcp.NewTool("report"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ReportProgress(ctx, 1, 2, "halfway")
+			ReportProgress(ctx, 2, 0, "")
+			return mcp.NewToolResultText("done"), nil
+		},
+	}
+	s, ctx, session := newCallTrackerServer(t, reporting)
+
+	t.Run("with a progress token", func(t *testing.T) {
+		s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"report","_meta":{"progressToken":"abc"}}}`))
+		require.Len(t, session.notifications, 2)
+
+		var params []string
+		for range 2 {
+			notification := <-session.notifications
+			assert.Equal(t, "notifications/progress", notification.Method)
+			data, err := json.Marshal(notification.Params.AdditionalFields)
+			require.NoError(t, err)
+			params = append(params, string(data))
+		}
+		assert.JSONEq(t, `{"progressToken":"abc","progress":1,"total":2,"message":"halfway"}`, params[0])
+		assert.JSONEq(t, `{"progressToken":"abc","progress":2}`, params[1])
+	})
+
+	t.Run("without a progress token", func(t *testing.T) {
+		s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"report"}}`))
+		assert.Empty(t, session.notifications)
+	})
+}
@@ -0,0 +1,240 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// fieldPath is a parsed entry of the "fields" parameter, e.g. "labels[].name" becomes ["labels", "name"].
+type fieldPath []string
+
+// WithFieldProjection adds the shared "fields" parameter to a tool.
+func WithFieldProjection() mcp.ToolOption {
+	return mcp.WithString("fields",
+		mcp.Description("Optional comma separated list of fields to return, e.g. 'number,title,user.login,labels[].name'. Nested fields use dots and arrays are projected element-wise. When omitted, all fields are returned."),
+	)
+}
+
+// OptionalFieldsParam returns the parsed "fields" parameter from the request. It accepts either
+// a comma separated string or an array of strings. A nil result means no projection was requested.
+func OptionalFieldsParam(r mcp.CallToolRequest) ([]fieldPath, error) {
+	var fields []string
+	switch v := r.GetArguments()["fields"].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		fields = strings.Split(v, ",")
+	default:
+		var err error
+		fields, err = OptionalStringArrayParam(r, "fields")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parseFieldPaths(fields)
+}
+
+func parseFieldPaths(fields []string) ([]fieldPath, error) {
+	var paths []fieldPath
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		var path fieldPath
+		for _, segment := range strings.Split(field, ".") {
+			// "[]" only documents that an array is traversed, arrays are always projected element-wise
+			segment = strings.TrimSuffix(strings.TrimSpace(segment), "[]")
+			if segment == "" {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+			path = append(path, segment)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// ProjectFields returns a copy of v that only contains the given field paths. v is expected to be
+// the result of decoding JSON, i.e. made of map[string]any, []any and scalar values.
+// Arrays are projected element-wise and fields that do not exist are omitted.
+//
+// An object with exactly one array field, like {"issues": [...], "pageInfo": {...}}, is a list
+// wrapped with metadata. Unless the paths select its own fields, the paths are applied to the
+// elements of the list and the metadata is kept as is.
+func ProjectFields(v any, paths []fieldPath) any {
+	if obj, ok := v.(map[string]any); ok {
+		if listKey, ok := wrappedListKey(obj); ok && !selectsField(obj, paths) {
+			out := make(map[string]any, len(obj))
+			for key, value := range obj {
+				out[key] = value
+			}
+			out[listKey], _ = projectValue(obj[listKey], paths)
+			return out
+		}
+	}
+	projected, _ := projectValue(v, paths)
+	return projected
+}
+
+// wrappedListKey returns the key of the only array field of obj.
+func wrappedListKey(obj map[string]any) (string, bool) {
+	listKey := ""
+	for key, value := range obj {
+		if _, ok := value.([]any); ok {
+			if listKey != "" {
+				return "", false
+			}
+			listKey = key
+		}
+	}
+	return listKey, listKey != ""
+}
+
+// selectsField reports whether any of the paths starts with a field of obj.
+func selectsField(obj map[string]any, paths []fieldPath) bool {
+	for _, path := range paths {
+		if len(path) == 0 {
+			return true
+		}
+		if _, exists := obj[path[0]]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+func projectValue(v any, paths []fieldPath) (any, bool) {
+	for _, path := range paths {
+		if len(path) == 0 {
+			// The whole value was selected
+			return v, true
+		}
+	}
+
+	switch value := v.(type) {
+	case []any:
+		out := make([]any, 0, len(value))
+		for _, elem := range value {
+			if projected, ok := projectValue(elem, paths); ok {
+				out = append(out, projected)
+			}
+		}
+		return out, true
+	case map[string]any:
+		out := make(map[string]any)
+		for _, path := range paths {
+			key := path[0]
+			if _, done := out[key]; done {
+				continue
+			}
+			field, exists := value[key]
+			if !exists {
+				continue
+			}
+			var subPaths []fieldPath
+			for _, other := range paths {
+				if other[0] == key {
+					subPaths = append(subPaths, other[1:])
+				}
+			}
+			if projected, ok := projectValue(field, subPaths); ok {
+				out[key] = projected
+			}
+		}
+		return out, true
+	default:
+		// A nested field was requested on a scalar or null
+		return nil, false
+	}
+}
+
+// projectToolResult applies the field projection to every JSON text content of a successful result.
+// Text that is not JSON, such as status messages, is returned unchanged.
+func projectToolResult(result *mcp.CallToolResult, paths []fieldPath) (*mcp.CallToolResult, error) {
+	if result == nil || result.IsError {
+		return result, nil
+	}
+	for i, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok || !json.Valid([]byte(text.Text)) {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader([]byte(text.Text)))
+		decoder.UseNumber()
+		var v any
+		if err := decoder.Decode(&v); err != nil {
+			return nil, fmt.Errorf("failed to decode result for projection: %w", err)
+		}
+
+		data, err := json.Marshal(ProjectFields(v, paths))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal projected result: %w", err)
+		}
+		text.Text = string(data)
+		result.Content[i] = text
+	}
+	return result, nil
+}
+
+// unprojectableTools are read tools whose result is raw content, such as logs and diffs, or a
+// link to it, so that selecting fields has no meaning for them.
+var unprojectableTools = map[string]bool{
+	"get_job_logs":                   true,
+	"download_workflow_run_artifact": true,
+	"get_pull_request_diff":          true,
+}
+
+// FieldProjectionTool adds the "fields" parameter to a read-only tool and projects its JSON results
+// down to the requested fields.
+func FieldProjectionTool(st server.ServerTool) server.ServerTool {
+	tool := st.Tool
+	if unprojectableTools[tool.Name] {
+		return st
+	}
+	if _, exists := tool.InputSchema.Properties["fields"]; exists {
+		// The tool defines its own fields parameter
+		return st
+	}
+	tool.InputSchema.Properties = cloneProperties(tool.InputSchema.Properties)
+	WithFieldProjection()(&tool)
+
+	handler := st.Handler
+	return server.ServerTool{
+		Tool: tool,
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			paths, err := OptionalFieldsParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			result, err := handler(ctx, request)
+			if err != nil || len(paths) == 0 {
+				return result, err
+			}
+
+			projected, err := projectToolResult(result, paths)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to apply fields", err), nil
+			}
+			return projected, nil
+		},
+	}
+}
+
+// cloneProperties copies a tool's schema properties so that shared parameters can be added
+// without mutating a schema that may be referenced elsewhere.
+func cloneProperties(properties map[string]any) map[string]any {
+	cloned := make(map[string]any, len(properties)+1)
+	for k, v := range properties {
+		cloned[k] = v
+	}
+	return cloned
+}
@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProjectFields(t *testing.T) {
+	issues := `[
+		{"number": 1, "title": "First", "body": "long body", "user": {"login": "octocat", "id": 1}, "labels": [{"name": "bug", "color": "red"}, {"name": "p1", "color": "blue"}]},
+		{"number": 2, "title": "Second", "body": "another body", "user": null, "labels": []}
+	]`
+
+	tests := []struct {
+		name     string
+		input    string
+		fields   []string
+		expected string
+	}{
+		{
+			name:     "top level fields of each array element",
+			input:    issues,
+			fields:   []string{"number", "title"},
+			expected: `[{"number":1,"title":"First"},{"number":2,"title":"Second"}]`,
+		},
+		{
+			name:     "nested and array fields",
+			input:    issues,
+			fields:   []string{"number", "user.login", "labels[].name"},
+			expected: `[{"number":1,"user":{"login":"octocat"},"labels":[{"name":"bug"},{"name":"p1"}]},{"number":2,"labels":[]}]`,
+		},
+		{
+			name:     "array traversal without brackets",
+			input:    issues,
+			fields:   []string{"labels.name"},
+			expected: `[{"labels":[{"name":"bug"},{"name":"p1"}]},{"labels":[]}]`,
+		},
+		{
+			name:     "whole nested object is kept",
+			input:    issues,
+			fields:   []string{"user"},
+			expected: `[{"user":{"id":1,"login":"octocat"}},{"user":null}]`,
+		},
+		{
+			name:     "wrapped results",
+			input:    `{"issues": [{"number": 1, "title": "First"}], "pageInfo": {"hasNextPage": true, "endCursor": "abc"}, "totalCount": 1}`,
+			fields:   []string{"issues[].number", "pageInfo.endCursor"},
+			expected: `{"issues":[{"number":1}],"pageInfo":{"endCursor":"abc"}}`,
+		},
+		{
+			name:     "fields of the elements of wrapped results",
+			input:    `{"issues": [{"number": 1, "title": "First", "user": {"login": "octocat"}}], "pageInfo": {"hasNextPage": true}, "totalCount": 1}`,
+			fields:   []string{"number", "title", "user.login"},
+			expected: `{"issues":[{"number":1,"title":"First","user":{"login":"octocat"}}],"pageInfo":{"hasNextPage":true},"totalCount":1}`,
+		},
+		{
+			name:     "unknown fields are omitted",
+			input:    `{"number": 1}`,
+			fields:   []string{"number", "missing", "number.deeper"},
+			expected: `{"number":1}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var v any
+			require.NoError(t, json.Unmarshal([]byte(tc.input), &v))
+
+			paths, err := parseFieldPaths(tc.fields)
+			require.NoError(t, err)
+
+			actual, err := json.Marshal(ProjectFields(v, paths))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func Test_OptionalFieldsParam(t *testing.T) {
+	t.Run("comma separated string", func(t *testing.T) {
+		paths, err := OptionalFieldsParam(createMCPRequest(map[string]any{"fields": "number, user.login,labels[].name"}))
+		require.NoError(t, err)
+		assert.Equal(t, []fieldPath{{"number"}, {"user", "login"}, {"labels", "name"}}, paths)
+	})
+
+	t.Run("array of strings", func(t *testing.T) {
+		paths, err := OptionalFieldsParam(createMCPRequest(map[string]any{"fields": []any{"number", "title"}}))
+		require.NoError(t, err)
+		assert.Equal(t, []fieldPath{{"number"}, {"title"}}, paths)
+	})
+
+	t.Run("not provided", func(t *testing.T) {
+		paths, err := OptionalFieldsParam(createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Nil(t, paths)
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		_, err := OptionalFieldsParam(createMCPRequest(map[string]any{"fields": "user..login"}))
+		require.Error(t, err)
+	})
+}
+
+func Test_FieldProjectionTool(t *testing.T) {
+	original := server.ServerTool{
+		Tool: mcp.NewTool("stub_tool",
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)}),
+			mcp.WithString("owner", mcp.Required()),
+		),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return MarshalledTextResult(map[string]any{"number": 1, "title": "First", "body": "long"}), nil
+		},
+	}
+
+	projected := FieldProjectionTool(original)
+	assert.Contains(t, projected.Tool.InputSchema.Properties, "fields")
+	assert.NotContains(t, original.Tool.InputSchema.Properties, "fields")
+	assert.ElementsMatch(t, []string{"owner"}, projected.Tool.InputSchema.Required)
+
+	t.Run("projects results when fields are requested", func(t *testing.T) {
+		result, err := projected.Handler(context.Background(), createMCPRequest(map[string]any{"fields": "number,title"}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"number":1,"title":"First"}`, getTextResult(t, result).Text)
+	})
+
+	t.Run("returns full results without fields", func(t *testing.T) {
+		result, err := projected.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"number":1,"title":"First","body":"long"}`, getTextResult(t, result).Text)
+	})
+
+	t.Run("tools without JSON results are not changed", func(t *testing.T) {
+		logs := server.ServerTool{
+			Tool:    mcp.NewTool("get_job_logs", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})),
+			Handler: original.Handler,
+		}
+		assert.NotContains(t, FieldProjectionTool(logs).Tool.InputSchema.Properties, "fields")
+	})
+}
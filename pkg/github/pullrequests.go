@@ -71,7 +71,7 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request", resp, body), nil
 			}
 
 			r, err := json.Marshal(pr)
@@ -190,7 +190,7 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create pull request: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create pull request", resp, body), nil
 			}
 
 			// Return minimal response with just essential information
@@ -349,7 +349,7 @@ func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t tra
 					if err != nil {
 						return nil, fmt.Errorf("failed to read response body: %w", err)
 					}
-					return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request: %s", string(body))), nil
+					return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to update pull request", resp, body), nil
 				}
 			}
 
@@ -449,7 +449,7 @@ func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t tra
 					if err != nil {
 						return nil, fmt.Errorf("failed to read response body: %w", err)
 					}
-					return mcp.NewToolResultError(fmt.Sprintf("failed to request reviewers: %s", string(body))), nil
+					return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to request reviewers", resp, body), nil
 				}
 			}
 
@@ -518,6 +518,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithFetchAll(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -552,6 +553,11 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			fetchAll, err := OptionalFetchAllParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination.PerPage = fetchAll.pageSize(request, pagination.PerPage)
 			opts := &github.PullRequestListOptions{
 				State:     state,
 				Head:      head,
@@ -568,7 +574,18 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			var prs []*github.PullRequest
+			var resp *github.Response
+			if fetchAll.FetchAll {
+				prs, resp, err = fetchRESTPages(ctx, pagination.Page, pagination.PerPage, fetchAll.MaxItems,
+					func(ctx context.Context, page int) ([]*github.PullRequest, *github.Response, error) {
+						pageOpts := *opts
+						pageOpts.Page = page
+						return client.PullRequests.List(ctx, owner, repo, &pageOpts)
+					})
+			} else {
+				prs, resp, err = client.PullRequests.List(ctx, owner, repo, opts)
+			}
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to list pull requests",
@@ -583,7 +600,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list pull requests", resp, body), nil
 			}
 
 			r, err := json.Marshal(prs)
@@ -676,7 +693,7 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to merge pull request: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to merge pull request", resp, body), nil
 			}
 
 			r, err := json.Marshal(result)
@@ -796,7 +813,7 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request files", resp, body), nil
 			}
 
 			r, err := json.Marshal(files)
@@ -862,7 +879,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request", resp, body), nil
 			}
 
 			// Get combined status for the head SHA
@@ -881,7 +898,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get combined status: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get combined status", resp, body), nil
 			}
 
 			r, err := json.Marshal(status)
@@ -963,7 +980,7 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request branch: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to update pull request branch", resp, body), nil
 			}
 
 			r, err := json.Marshal(result)
@@ -1035,7 +1052,7 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request comments: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request comments", resp, body), nil
 			}
 
 			r, err := json.Marshal(comments)
@@ -1101,7 +1118,7 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request reviews: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request reviews", resp, body), nil
 			}
 
 			r, err := json.Marshal(reviews)
@@ -1782,7 +1799,7 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request diff: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request diff", resp, body), nil
 			}
 
 			defer func() { _ = resp.Body.Close() }()
@@ -1860,7 +1877,7 @@ func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelpe
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to request copilot review: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to request copilot review", resp, body), nil
 			}
 
 			// Return nothing on success, as there's not much value in returning the Pull Request itself
@@ -1,12 +1,17 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"path"
+	"reflect"
 	"strings"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
@@ -188,7 +193,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get commit", resp, body), nil
 			}
 
 			// Convert to minimal commit
@@ -203,6 +208,149 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 		}
 }
 
+const (
+	// DefaultComparePatchBytes is the default budget for the patches returned by compare_refs.
+	DefaultComparePatchBytes = 50000
+	// MaxComparePatchBytes is the upper bound for max_patch_bytes.
+	MaxComparePatchBytes = 1000000
+)
+
+// CompareRefs creates a tool to compare two branches, tags or commits of a repository.
+func CompareRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_refs",
+			mcp.WithDescription(t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two branches, tags or commits of a GitHub repository. Returns how far head is ahead of and behind base, the commits of head that are not in base and the changed files with their stats. The changed files are only included on the first page of commits.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Branch name, tag name or commit SHA to compare from, e.g. v1.4.0"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Branch name, tag name or commit SHA to compare to, e.g. main. Use 'owner:branch' for a branch of a fork"),
+			),
+			mcp.WithBoolean("include_patches",
+				mcp.Description("Whether to include the patch of each changed file. Default is false."),
+			),
+			mcp.WithNumber("max_patch_bytes",
+				mcp.Description(fmt.Sprintf("Budget for the total size of the included patches in bytes (default %d, max %d). Patches beyond the budget are omitted and marked with patch_omitted", DefaultComparePatchBytes, MaxComparePatchBytes)),
+				mcp.Min(0),
+				mcp.Max(MaxComparePatchBytes),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePatches, err := OptionalParam[bool](request, "include_patches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patchBudget, err := OptionalIntParamWithDefault(request, "max_patch_bytes", DefaultComparePatchBytes)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if patchBudget < 0 || patchBudget > MaxComparePatchBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("max_patch_bytes must be between 0 and %d", MaxComparePatchBytes)), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to compare %s...%s", base, head),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(convertToMinimalComparison(comparison, includePatches, patchBudget))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// convertToMinimalComparison converts a GitHub API CommitsComparison to MinimalComparison. Patches
+// are included in file order for as long as they fit in patchBudget bytes.
+func convertToMinimalComparison(comparison *github.CommitsComparison, includePatches bool, patchBudget int) MinimalComparison {
+	result := MinimalComparison{
+		Status:          comparison.GetStatus(),
+		AheadBy:         comparison.GetAheadBy(),
+		BehindBy:        comparison.GetBehindBy(),
+		TotalCommits:    comparison.GetTotalCommits(),
+		MergeBaseCommit: comparison.GetMergeBaseCommit().GetSHA(),
+		HTMLURL:         comparison.GetHTMLURL(),
+		Commits:         make([]MinimalCommit, 0, len(comparison.Commits)),
+		Files:           make([]MinimalComparisonFile, 0, len(comparison.Files)),
+	}
+	for _, commit := range comparison.Commits {
+		result.Commits = append(result.Commits, convertToMinimalCommit(commit, false))
+	}
+	for _, file := range comparison.Files {
+		minimalFile := MinimalComparisonFile{
+			MinimalCommitFile: MinimalCommitFile{
+				Filename:  file.GetFilename(),
+				Status:    file.GetStatus(),
+				Additions: file.GetAdditions(),
+				Deletions: file.GetDeletions(),
+				Changes:   file.GetChanges(),
+			},
+			PreviousFilename: file.GetPreviousFilename(),
+		}
+		if includePatches && file.GetPatch() != "" {
+			if len(file.GetPatch()) <= patchBudget {
+				minimalFile.Patch = file.GetPatch()
+				patchBudget -= len(file.GetPatch())
+			} else {
+				minimalFile.PatchOmitted = true
+			}
+		}
+		result.Stats.Additions += file.GetAdditions()
+		result.Stats.Deletions += file.GetDeletions()
+		result.Stats.Total += file.GetChanges()
+		result.Files = append(result.Files, minimalFile)
+	}
+	return result
+}
+
 // ListCommits creates a tool to get commits of a branch in a repository.
 func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_commits",
@@ -226,6 +374,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("Author username or email address to filter commits by"),
 			),
 			WithPagination(),
+			WithFetchAll(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -248,11 +397,16 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			fetchAll, err := OptionalFetchAllParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			// Set default perPage to 30 if not provided
 			perPage := pagination.PerPage
 			if perPage == 0 {
 				perPage = 30
 			}
+			perPage = fetchAll.pageSize(request, perPage)
 			opts := &github.CommitsListOptions{
 				SHA:    sha,
 				Author: author,
@@ -266,7 +420,19 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+
+			var commits []*github.RepositoryCommit
+			var resp *github.Response
+			if fetchAll.FetchAll {
+				commits, resp, err = fetchRESTPages(ctx, pagination.Page, perPage, fetchAll.MaxItems,
+					func(ctx context.Context, page int) ([]*github.RepositoryCommit, *github.Response, error) {
+						pageOpts := *opts
+						pageOpts.Page = page
+						return client.Repositories.ListCommits(ctx, owner, repo, &pageOpts)
+					})
+			} else {
+				commits, resp, err = client.Repositories.ListCommits(ctx, owner, repo, opts)
+			}
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					fmt.Sprintf("failed to list commits: %s", sha),
@@ -281,7 +447,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list commits", resp, body), nil
 			}
 
 			// Convert to minimal commits
@@ -316,6 +482,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithFetchAll(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -330,6 +497,11 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			fetchAll, err := OptionalFetchAllParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination.PerPage = fetchAll.pageSize(request, pagination.PerPage)
 
 			opts := &github.BranchListOptions{
 				ListOptions: github.ListOptions{
@@ -343,7 +515,18 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+			var branches []*github.Branch
+			var resp *github.Response
+			if fetchAll.FetchAll {
+				branches, resp, err = fetchRESTPages(ctx, pagination.Page, pagination.PerPage, fetchAll.MaxItems,
+					func(ctx context.Context, page int) ([]*github.Branch, *github.Response, error) {
+						pageOpts := *opts
+						pageOpts.Page = page
+						return client.Repositories.ListBranches(ctx, owner, repo, &pageOpts)
+					})
+			} else {
+				branches, resp, err = client.Repositories.ListBranches(ctx, owner, repo, opts)
+			}
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to list branches",
@@ -358,7 +541,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list branches: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list branches", resp, body), nil
 			}
 
 			// Convert to minimal branches
@@ -477,7 +660,7 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create/update file", resp, body), nil
 			}
 
 			r, err := json.Marshal(fileContent)
@@ -562,7 +745,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create repository: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create repository", resp, body), nil
 			}
 
 			// Return minimal response with just essential information
@@ -868,7 +1051,7 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to fork repository", resp, body), nil
 			}
 
 			// Return minimal response with just essential information
@@ -955,6 +1138,313 @@ func RenameRepository(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// RepositorySettings are the settings of a repository changed by update_repository.
+type RepositorySettings struct {
+	Description         string   `json:"description"`
+	Homepage            string   `json:"homepage"`
+	Topics              []string `json:"topics"`
+	Visibility          string   `json:"visibility"`
+	DefaultBranch       string   `json:"default_branch"`
+	AllowMergeCommit    bool     `json:"allow_merge_commit"`
+	AllowSquashMerge    bool     `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool     `json:"allow_rebase_merge"`
+	AllowAutoMerge      bool     `json:"allow_auto_merge"`
+	AllowUpdateBranch   bool     `json:"allow_update_branch"`
+	DeleteBranchOnMerge bool     `json:"delete_branch_on_merge"`
+	HasIssues           bool     `json:"has_issues"`
+	HasWiki             bool     `json:"has_wiki"`
+	HasDiscussions      bool     `json:"has_discussions"`
+	Archived            bool     `json:"archived"`
+}
+
+func repositorySettings(repo *github.Repository) RepositorySettings {
+	topics := repo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return RepositorySettings{
+		Description:         repo.GetDescription(),
+		Homepage:            repo.GetHomepage(),
+		Topics:              topics,
+		Visibility:          repo.GetVisibility(),
+		DefaultBranch:       repo.GetDefaultBranch(),
+		AllowMergeCommit:    repo.GetAllowMergeCommit(),
+		AllowSquashMerge:    repo.GetAllowSquashMerge(),
+		AllowRebaseMerge:    repo.GetAllowRebaseMerge(),
+		AllowAutoMerge:      repo.GetAllowAutoMerge(),
+		AllowUpdateBranch:   repo.GetAllowUpdateBranch(),
+		DeleteBranchOnMerge: repo.GetDeleteBranchOnMerge(),
+		HasIssues:           repo.GetHasIssues(),
+		HasWiki:             repo.GetHasWiki(),
+		HasDiscussions:      repo.GetHasDiscussions(),
+		Archived:            repo.GetArchived(),
+	}
+}
+
+// SettingChange is the value of a repository setting before and after update_repository.
+type SettingChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// UpdateRepositoryResult is the result of update_repository.
+type UpdateRepositoryResult struct {
+	URL string `json:"url"`
+	// Changes has the settings whose values changed, by name
+	Changes  map[string]SettingChange `json:"changes"`
+	Settings RepositorySettings       `json:"settings"`
+}
+
+// diffSettings returns the settings that differ between before and after.
+func diffSettings(before, after RepositorySettings) (map[string]SettingChange, error) {
+	var b, a map[string]any
+	for _, s := range []struct {
+		settings RepositorySettings
+		out      *map[string]any
+	}{{before, &b}, {after, &a}} {
+		data, err := json.Marshal(s.settings)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, s.out); err != nil {
+			return nil, err
+		}
+	}
+	changes := map[string]SettingChange{}
+	for name, value := range a {
+		if !reflect.DeepEqual(b[name], value) {
+			changes[name] = SettingChange{Before: b[name], After: value}
+		}
+	}
+	return changes, nil
+}
+
+// UpdateRepository creates a tool to update the settings and metadata of a repository.
+func UpdateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_repository",
+			mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_DESCRIPTION", "Update the settings and metadata of a GitHub repository. Only the provided settings are changed, and the result lists the settings before and after the change")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_REPOSITORY_USER_TITLE", "Update repository settings"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the repository"),
+			),
+			mcp.WithString("homepage",
+				mcp.Description("URL of the homepage of the project"),
+			),
+			mcp.WithArray("topics",
+				mcp.Description("Topics of the repository, replacing the current ones. Topics are lowercase"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Visibility of the repository. internal is only available to organizations of enterprises"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			mcp.WithString("default_branch",
+				mcp.Description("Name of the default branch, which must exist"),
+			),
+			mcp.WithBoolean("allow_merge_commit",
+				mcp.Description("Allow merging pull requests with a merge commit"),
+			),
+			mcp.WithBoolean("allow_squash_merge",
+				mcp.Description("Allow squash merging pull requests"),
+			),
+			mcp.WithBoolean("allow_rebase_merge",
+				mcp.Description("Allow rebase merging pull requests"),
+			),
+			mcp.WithBoolean("allow_auto_merge",
+				mcp.Description("Allow auto-merge on pull requests"),
+			),
+			mcp.WithBoolean("allow_update_branch",
+				mcp.Description("Suggest updating pull request branches that are behind their base"),
+			),
+			mcp.WithBoolean("delete_branch_on_merge",
+				mcp.Description("Delete head branches when pull requests are merged"),
+			),
+			mcp.WithBoolean("has_issues",
+				mcp.Description("Enable issues"),
+			),
+			mcp.WithBoolean("has_wiki",
+				mcp.Description("Enable the wiki"),
+			),
+			mcp.WithBoolean("has_discussions",
+				mcp.Description("Enable discussions"),
+			),
+			mcp.WithBoolean("archived",
+				mcp.Description("Archive the repository, making it read-only, or unarchive it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			update := &github.Repository{}
+			updateNeeded := false
+			for _, field := range []struct {
+				name  string
+				value **string
+			}{
+				{"description", &update.Description},
+				{"homepage", &update.Homepage},
+				{"visibility", &update.Visibility},
+				{"default_branch", &update.DefaultBranch},
+			} {
+				if v, ok, err := OptionalParamOK[string](request, field.name); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				} else if ok {
+					*field.value = github.Ptr(v)
+					updateNeeded = true
+				}
+			}
+			for _, field := range []struct {
+				name  string
+				value **bool
+			}{
+				{"allow_merge_commit", &update.AllowMergeCommit},
+				{"allow_squash_merge", &update.AllowSquashMerge},
+				{"allow_rebase_merge", &update.AllowRebaseMerge},
+				{"allow_auto_merge", &update.AllowAutoMerge},
+				{"allow_update_branch", &update.AllowUpdateBranch},
+				{"delete_branch_on_merge", &update.DeleteBranchOnMerge},
+				{"has_issues", &update.HasIssues},
+				{"has_wiki", &update.HasWiki},
+				{"has_discussions", &update.HasDiscussions},
+			} {
+				if v, ok, err := OptionalParamOK[bool](request, field.name); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				} else if ok {
+					*field.value = github.Ptr(v)
+					updateNeeded = true
+				}
+			}
+			archived, archivedProvided, err := OptionalParamOK[bool](request, "archived")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, topicsProvided := request.GetArguments()["topics"]
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !updateNeeded && !archivedProvided && !topicsProvided {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			current, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			before := repositorySettings(current)
+			after := before
+
+			edit := func(update *github.Repository) *mcp.CallToolResult {
+				updated, resp, err := client.Repositories.Edit(ctx, owner, repo, update)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to update repository",
+						resp,
+						err,
+					)
+				}
+				_ = resp.Body.Close()
+				// The topics of the response are not changed by the edit
+				updated.Topics = after.Topics
+				after = repositorySettings(updated)
+				return nil
+			}
+
+			// Steps that succeeded are not undone when a later step fails, so the settings that
+			// were already changed, e.g. an unarchived repository, are reported with the error
+			failed := func(errResult *mcp.CallToolResult) (*mcp.CallToolResult, error) {
+				changes, err := diffSettings(before, after)
+				if err != nil || len(changes) == 0 {
+					return errResult, nil
+				}
+				r, err := json.Marshal(changes)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				errResult.Content = append(errResult.Content, mcp.NewTextContent(fmt.Sprintf("These settings were changed before the failure and were not reverted: %s", r)))
+				return errResult, nil
+			}
+
+			// An archived repository is read-only, so it is unarchived first and archived last
+			if archivedProvided && !archived && before.Archived {
+				if errResult := edit(&github.Repository{Archived: github.Ptr(false)}); errResult != nil {
+					return errResult, nil
+				}
+			}
+			if updateNeeded {
+				if errResult := edit(update); errResult != nil {
+					return failed(errResult)
+				}
+			}
+			if topicsProvided {
+				replaced, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+				if err != nil {
+					return failed(ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to replace topics",
+						resp,
+						err,
+					))
+				}
+				_ = resp.Body.Close()
+				after.Topics = replaced
+				if after.Topics == nil {
+					after.Topics = []string{}
+				}
+			}
+			if archivedProvided && archived && !before.Archived {
+				if errResult := edit(&github.Repository{Archived: github.Ptr(true)}); errResult != nil {
+					return failed(errResult)
+				}
+			}
+
+			changes, err := diffSettings(before, after)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare settings: %w", err)
+			}
+
+			r, err := json.Marshal(UpdateRepositoryResult{
+				URL:      current.GetHTMLURL(),
+				Changes:  changes,
+				Settings: after,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // DeleteFile creates a tool to delete a file in a GitHub repository.
 // This tool uses a more roundabout way of deleting a file than just using the client.Repositories.DeleteFile.
 // This is because REST file deletion endpoint (and client.Repositories.DeleteFile) don't add commit signing to the deletion commit,
@@ -1040,7 +1530,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get commit", resp, body), nil
 			}
 
 			// Create a tree entry for the file deletion by setting SHA to nil
@@ -1069,7 +1559,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create tree: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create tree", resp, body), nil
 			}
 
 			// Create a new commit with the new tree
@@ -1093,7 +1583,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create commit", resp, body), nil
 			}
 
 			// Update the branch reference to point to the new commit
@@ -1113,7 +1603,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update reference: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to update reference", resp, body), nil
 			}
 
 			// Create a response similar to what the DeleteFile API would return
@@ -1232,6 +1722,10 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
+// pushFilesSteps is the number of steps push_files reports progress for: resolving the branch and
+// creating the tree, the commit and the updated reference.
+const pushFilesSteps = 4
+
 // PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
 func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("push_files",
@@ -1316,6 +1810,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			ReportProgress(ctx, 1, pushFilesSteps, "Resolved branch "+branch)
 
 			// Get the commit object that the branch points to
 			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
@@ -1366,6 +1861,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			ReportProgress(ctx, 2, pushFilesSteps, fmt.Sprintf("Created tree with %d files", len(entries)))
 
 			// Create a new commit
 			commit := &github.Commit{
@@ -1382,6 +1878,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			ReportProgress(ctx, 3, pushFilesSteps, "Created commit "+newCommit.GetSHA())
 
 			// Update the reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
@@ -1394,6 +1891,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			ReportProgress(ctx, pushFilesSteps, pushFilesSteps, "Updated branch "+branch)
 
 			r, err := json.Marshal(updatedRef)
 			if err != nil {
@@ -1404,13 +1902,113 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 		}
 }
 
-// ListTags creates a tool to list tags in a GitHub repository.
-func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("list_tags",
-			mcp.WithDescription(t("TOOL_LIST_TAGS_DESCRIPTION", "List git tags in a GitHub repository")),
+// fileChange is a change of a file in a commit_changes call.
+type fileChange struct {
+	Path         string
+	Action       string
+	Content      *string
+	Encoding     string
+	PreviousPath string
+	Executable   *bool
+}
+
+// parseFileChanges parses the changes parameter of commit_changes.
+func parseFileChanges(request mcp.CallToolRequest) ([]fileChange, error) {
+	items, ok := request.GetArguments()["changes"].([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("changes must be a non-empty array of objects")
+	}
+	changes := make([]fileChange, 0, len(items))
+	// Each change is checked against the base tree, so a path touched by two changes would lose
+	// the effect of the first one
+	changed := make(map[string]bool)
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("changes[%d] must be an object", i)
+		}
+		var change fileChange
+		change.Path, _ = m["path"].(string)
+		if change.Path == "" {
+			return nil, fmt.Errorf("changes[%d]: missing required parameter: path", i)
+		}
+		change.Action, _ = m["action"].(string)
+		if change.Action == "" {
+			change.Action = "upsert"
+		}
+		change.Encoding, _ = m["encoding"].(string)
+		change.PreviousPath, _ = m["previous_path"].(string)
+		if content, ok := m["content"].(string); ok {
+			change.Content = &content
+		}
+		if executable, ok := m["executable"].(bool); ok {
+			change.Executable = &executable
+		}
+
+		switch change.Action {
+		case "upsert":
+			if change.Content == nil && change.Executable == nil {
+				return nil, fmt.Errorf("changes[%d]: content is required to create or update %s", i, change.Path)
+			}
+		case "delete":
+			if change.Content != nil {
+				return nil, fmt.Errorf("changes[%d]: content is not allowed when deleting %s", i, change.Path)
+			}
+		case "rename":
+			if change.PreviousPath == "" {
+				return nil, fmt.Errorf("changes[%d]: previous_path is required to rename to %s", i, change.Path)
+			}
+		default:
+			return nil, fmt.Errorf("changes[%d]: unknown action %q, must be upsert, delete or rename", i, change.Action)
+		}
+		if change.Encoding != "" && change.Encoding != "utf-8" && change.Encoding != "base64" {
+			return nil, fmt.Errorf("changes[%d]: unknown encoding %q, must be utf-8 or base64", i, change.Encoding)
+		}
+		for _, path := range []string{change.Path, change.PreviousPath} {
+			if path == "" {
+				continue
+			}
+			if changed[path] {
+				return nil, fmt.Errorf("changes[%d]: %s is changed more than once, combine its changes into a single change", i, path)
+			}
+			changed[path] = true
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// fileMode returns the mode of a changed file: executable if requested, otherwise the mode of the
+// existing file, which keeps symlinks and executables intact.
+func fileMode(executable *bool, existing *github.TreeEntry) string {
+	switch {
+	case executable != nil && *executable:
+		return "100755"
+	case executable != nil:
+		return "100644"
+	case existing != nil:
+		return existing.GetMode()
+	default:
+		return "100644"
+	}
+}
+
+// CommitChangesResult is the result of commit_changes.
+type CommitChangesResult struct {
+	SHA       string `json:"sha"`
+	ParentSHA string `json:"parent_sha"`
+	Branch    string `json:"branch"`
+	HTMLURL   string `json:"html_url,omitempty"`
+	Changes   int    `json:"changes"`
+}
+
+// CommitChanges creates a tool to add, update, delete and rename files of a branch in a single commit.
+func CommitChanges(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("commit_changes",
+			mcp.WithDescription(t("TOOL_COMMIT_CHANGES_DESCRIPTION", "Add, update, delete and rename files of a branch in a single atomic commit. Either all changes are committed or none. Use expected_parent_sha to make sure the branch has not moved since its files were read")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
-				ReadOnlyHint: ToBoolPtr(true),
+				Title:        t("TOOL_COMMIT_CHANGES_USER_TITLE", "Commit changes to files"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -1420,78 +2018,54 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			WithPagination(),
-		),
-		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := RequiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := RequiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			opts := &github.ListOptions{
-				Page:    pagination.Page,
-				PerPage: pagination.PerPage,
-			}
-
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
-
-			tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, opts)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to list tags",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list tags: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(tags)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
-		}
-}
-
-// GetTag creates a tool to get details about a specific tag in a GitHub repository.
-func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("get_tag",
-			mcp.WithDescription(t("TOOL_GET_TAG_DESCRIPTION", "Get details about a specific git tag in a GitHub repository")),
-			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
-				ReadOnlyHint: ToBoolPtr(true),
-			}),
-			mcp.WithString("owner",
+			mcp.WithString("branch",
 				mcp.Required(),
-				mcp.Description("Repository owner"),
+				mcp.Description("Branch to commit to"),
 			),
-			mcp.WithString("repo",
+			mcp.WithString("message",
 				mcp.Required(),
-				mcp.Description("Repository name"),
+				mcp.Description("Commit message"),
 			),
-			mcp.WithString("tag",
+			mcp.WithArray("changes",
 				mcp.Required(),
-				mcp.Description("Tag name"),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "Path of the file",
+							},
+							"action": map[string]interface{}{
+								"type":        "string",
+								"enum":        []string{"upsert", "delete", "rename"},
+								"description": "upsert (default) creates or updates the file, delete removes it, rename moves previous_path to path",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "New content of the file. Optional for renames and executable changes, which keep the current content",
+							},
+							"encoding": map[string]interface{}{
+								"type":        "string",
+								"enum":        []string{"utf-8", "base64"},
+								"description": "Encoding of content, base64 for binary files. Default is utf-8",
+							},
+							"previous_path": map[string]interface{}{
+								"type":        "string",
+								"description": "Current path of a renamed file",
+							},
+							"executable": map[string]interface{}{
+								"type":        "boolean",
+								"description": "Whether the file is executable. The current mode is kept if omitted",
+							},
+						},
+					}),
+				mcp.Description("Changes to commit. Each path, including previous_path of renames, may appear in only one change"),
+			),
+			mcp.WithString("expected_parent_sha",
+				mcp.Description("SHA the branch is expected to point to. The commit is rejected if the branch has moved"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1503,7 +2077,19 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			tag, err := RequiredParam[string](request, "tag")
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedParentSHA, err := OptionalParam[string](request, "expected_parent_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			changes, err := parseFileChanges(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -1513,45 +2099,140 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// First get the tag reference
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/tags/"+tag)
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get tag reference",
+					"failed to get branch reference",
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get tag reference: %s", string(body))), nil
+			parentSHA := ref.GetObject().GetSHA()
+			if expectedParentSHA != "" && parentSHA != expectedParentSHA {
+				return mcp.NewToolResultError(fmt.Sprintf("branch %s has moved to %s, expected %s. Read the files again and retry", branch, parentSHA, expectedParentSHA)), nil
 			}
 
-			// Then get the tag object
-			tagObj, resp, err := client.Git.GetTag(ctx, owner, repo, *ref.Object.SHA)
+			parent, resp, err := client.Git.GetCommit(ctx, owner, repo, parentSHA)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get tag object",
+					"failed to get parent commit",
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			baseTreeSHA := parent.GetTree().GetSHA()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
+			// Existing files are looked up for deletions, renames and to keep their modes
+			trees := newTreeReader(client, owner, repo)
+			existingFile := func(path string) (*github.TreeEntry, *mcp.CallToolResult) {
+				entry, resp, err := trees.entry(ctx, baseTreeSHA, path)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get tree", resp, err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get tag object: %s", string(body))), nil
+				if entry != nil && entry.GetType() != "blob" {
+					return nil, mcp.NewToolResultError(fmt.Sprintf("%s is not a file", path))
+				}
+				return entry, nil
 			}
 
-			r, err := json.Marshal(tagObj)
+			var entries []*github.TreeEntry
+			for _, change := range changes {
+				var existing *github.TreeEntry
+				switch change.Action {
+				case "delete":
+					deleted, errResult := existingFile(change.Path)
+					if errResult != nil {
+						return errResult, nil
+					}
+					if deleted == nil {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot delete %s, the file does not exist", change.Path)), nil
+					}
+					// An entry without SHA and content deletes the file
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(change.Path),
+						Mode: github.Ptr(deleted.GetMode()),
+						Type: github.Ptr("blob"),
+					})
+					continue
+				case "rename":
+					previous, errResult := existingFile(change.PreviousPath)
+					if errResult != nil {
+						return errResult, nil
+					}
+					if previous == nil {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot rename %s, the file does not exist", change.PreviousPath)), nil
+					}
+					target, errResult := existingFile(change.Path)
+					if errResult != nil {
+						return errResult, nil
+					}
+					if target != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot rename %s to %s, the file already exists", change.PreviousPath, change.Path)), nil
+					}
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(change.PreviousPath),
+						Mode: github.Ptr(previous.GetMode()),
+						Type: github.Ptr("blob"),
+					})
+					existing = previous
+				default:
+					var errResult *mcp.CallToolResult
+					existing, errResult = existingFile(change.Path)
+					if errResult != nil {
+						return errResult, nil
+					}
+				}
+
+				entry := &github.TreeEntry{
+					Path: github.Ptr(change.Path),
+					Mode: github.Ptr(fileMode(change.Executable, existing)),
+					Type: github.Ptr("blob"),
+				}
+				switch {
+				case change.Content == nil:
+					// Renames and mode changes keep the current content
+					if existing == nil {
+						return mcp.NewToolResultError(fmt.Sprintf("content is required to create %s", change.Path)), nil
+					}
+					entry.SHA = existing.SHA
+				case change.Encoding == "base64":
+					if _, err := base64.StdEncoding.DecodeString(*change.Content); err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("content of %s is not valid base64: %s", change.Path, err)), nil
+					}
+					blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+						Content:  change.Content,
+						Encoding: github.Ptr("base64"),
+					})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to create blob for %s", change.Path),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					entry.SHA = blob.SHA
+				default:
+					entry.Content = change.Content
+				}
+				entries = append(entries, entry)
+			}
+
+			commit, errResult := commitToBranch(ctx, client, owner, repo, ref, baseTreeSHA, message, entries)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			r, err := json.Marshal(CommitChangesResult{
+				SHA:       commit.GetSHA(),
+				ParentSHA: parentSHA,
+				Branch:    branch,
+				HTMLURL:   commit.GetHTMLURL(),
+				Changes:   len(changes),
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1560,12 +2241,58 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 		}
 }
 
-// ListReleases creates a tool to list releases in a GitHub repository.
-func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("list_releases",
-			mcp.WithDescription(t("TOOL_LIST_RELEASES_DESCRIPTION", "List releases in a GitHub repository")),
+// commitToBranch creates a commit of the tree entries on top of the commit the branch reference
+// points to, and moves the branch to it. The update is not forced, so that commits pushed in the
+// meantime are never overwritten.
+func commitToBranch(ctx context.Context, client *github.Client, owner, repo string, ref *github.Reference, baseTreeSHA, message string, entries []*github.TreeEntry) (*github.Commit, *mcp.CallToolResult) {
+	tree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseTreeSHA, entries)
+	if err != nil {
+		return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create tree",
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+
+	commit, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.Ptr(message),
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: github.Ptr(ref.GetObject().GetSHA())}},
+	}, nil)
+	if err != nil {
+		return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create commit",
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+
+	updated := &github.Reference{Ref: ref.Ref, Object: &github.GitObject{SHA: commit.SHA}}
+	_, resp, err = client.Git.UpdateRef(ctx, owner, repo, updated, false)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+			branch := strings.TrimPrefix(ref.GetRef(), "refs/heads/")
+			return nil, mcp.NewToolResultError(fmt.Sprintf("branch %s has moved while committing, nothing was changed. Read the files again and retry", branch))
+		}
+		return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to update reference",
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+
+	return commit, nil
+}
+
+// ListTags creates a tool to list tags in a GitHub repository.
+func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_tags",
+			mcp.WithDescription(t("TOOL_LIST_TAGS_DESCRIPTION", "List git tags in a GitHub repository")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
+				Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -1602,9 +2329,13 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			releases, resp, err := client.Repositories.ListReleases(ctx, owner, repo, opts)
+			tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, opts)
 			if err != nil {
-				return nil, fmt.Errorf("failed to list releases: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list tags",
+					resp,
+					err,
+				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -1613,10 +2344,10 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list releases: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list tags", resp, body), nil
 			}
 
-			r, err := json.Marshal(releases)
+			r, err := json.Marshal(tags)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1625,12 +2356,12 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
-// GetLatestRelease creates a tool to get the latest release in a GitHub repository.
-func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("get_latest_release",
-			mcp.WithDescription(t("TOOL_GET_LATEST_RELEASE_DESCRIPTION", "Get the latest release in a GitHub repository")),
+// GetTag creates a tool to get details about a specific tag in a GitHub repository.
+func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_tag",
+			mcp.WithDescription(t("TOOL_GET_TAG_DESCRIPTION", "Get details about a specific git tag in a GitHub repository")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_LATEST_RELEASE_USER_TITLE", "Get latest release"),
+				Title:        t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -1641,6 +2372,10 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1651,15 +2386,24 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			release, resp, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+			// First get the tag reference
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/tags/"+tag)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get latest release: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get tag reference",
+					resp,
+					err,
+				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -1668,10 +2412,29 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get latest release: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get tag reference", resp, body), nil
 			}
 
-			r, err := json.Marshal(release)
+			// Then get the tag object
+			tagObj, resp, err := client.Git.GetTag(ctx, owner, repo, *ref.Object.SHA)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get tag object",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get tag object", resp, body), nil
+			}
+
+			r, err := json.Marshal(tagObj)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1680,12 +2443,23 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
-func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("get_release_by_tag",
-			mcp.WithDescription(t("TOOL_GET_RELEASE_BY_TAG_DESCRIPTION", "Get a specific release by its tag name in a GitHub repository")),
+// CreatedTag is the result of create_tag.
+type CreatedTag struct {
+	Tag       string `json:"tag"`
+	Ref       string `json:"ref"`
+	CommitSHA string `json:"commit_sha"`
+	// TagSHA is the SHA of the tag object of an annotated tag
+	TagSHA    string `json:"tag_sha,omitempty"`
+	Annotated bool   `json:"annotated"`
+}
+
+// CreateTag creates a tool to create a lightweight or annotated tag in a GitHub repository.
+func CreateTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_DESCRIPTION", "Create a git tag in a GitHub repository. The tag is annotated when a message is provided, and lightweight otherwise")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_RELEASE_BY_TAG_USER_TITLE", "Get a release by tag name"),
-				ReadOnlyHint: ToBoolPtr(true),
+				Title:        t("TOOL_CREATE_TAG_USER_TITLE", "Create tag"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -1699,6 +2473,18 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Required(),
 				mcp.Description("Tag name (e.g., 'v1.0.0')"),
 			),
+			mcp.WithString("target",
+				mcp.Description("Branch name or commit SHA to tag. Defaults to the default branch"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Message of an annotated tag"),
+			),
+			mcp.WithString("tagger_name",
+				mcp.Description("Name of the tagger of an annotated tag. Defaults to the authenticated user"),
+			),
+			mcp.WithString("tagger_email",
+				mcp.Description("Email of the tagger of an annotated tag, required with tagger_name"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1713,31 +2499,812 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			target, err := OptionalParam[string](request, "target")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if target == "" {
+				target = "HEAD"
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerName, err := OptionalParam[string](request, "tagger_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerEmail, err := OptionalParam[string](request, "tagger_email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (taggerName != "" || taggerEmail != "") && message == "" {
+				return mcp.NewToolResultError("a message is required for a tag with a tagger"), nil
+			}
+			if (taggerName == "") != (taggerEmail == "") {
+				return mcp.NewToolResultError("tagger_name and tagger_email must be provided together"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			release, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+			commitSHA, resp, err := client.Repositories.GetCommitSHA1(ctx, owner, repo, target, "")
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to get release by tag: %s", tag),
+					fmt.Sprintf("failed to resolve target: %s", target),
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
+			result := CreatedTag{Tag: tag, Ref: "refs/tags/" + tag, CommitSHA: commitSHA}
+			refSHA := commitSHA
+			if message != "" {
+				tagObject := &github.Tag{
+					Tag:     github.Ptr(tag),
+					Message: github.Ptr(message),
+					Object:  &github.GitObject{SHA: github.Ptr(commitSHA), Type: github.Ptr("commit")},
+				}
+				if taggerName != "" {
+					tagObject.Tagger = &github.CommitAuthor{
+						Name:  github.Ptr(taggerName),
+						Email: github.Ptr(taggerEmail),
+					}
+				}
+				created, resp, err := client.Git.CreateTag(ctx, owner, repo, tagObject)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create tag object: %s", tag),
+						resp,
+						err,
+					), nil
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get release by tag: %s", string(body))), nil
+				defer func() { _ = resp.Body.Close() }()
+				refSHA = created.GetSHA()
+				result.TagSHA = refSHA
+				result.Annotated = true
 			}
 
-			r, err := json.Marshal(release)
+			// An annotated tag only exists once a reference points to its tag object
+			_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr(result.Ref),
+				Object: &github.GitObject{SHA: github.Ptr(refSHA)},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create tag: %s", tag),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteTag creates a tool to delete a tag in a GitHub repository.
+func DeleteTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_tag",
+			mcp.WithDescription(t("TOOL_DELETE_TAG_DESCRIPTION", "Delete a git tag in a GitHub repository. A release of the tag becomes a draft")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_TAG_USER_TITLE", "Delete tag"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name (e.g., 'v1.0.0')"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Git.DeleteRef(ctx, owner, repo, "refs/tags/"+tag)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete tag: %s", tag),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("tag %s deleted", tag)), nil
+		}
+}
+
+// ListReleases creates a tool to list releases in a GitHub repository.
+func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_releases",
+			mcp.WithDescription(t("TOOL_LIST_RELEASES_DESCRIPTION", "List releases in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			releases, resp, err := client.Repositories.ListReleases(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list releases: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list releases", resp, body), nil
+			}
+
+			r, err := json.Marshal(releases)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetLatestRelease creates a tool to get the latest release in a GitHub repository.
+func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_latest_release",
+			mcp.WithDescription(t("TOOL_GET_LATEST_RELEASE_DESCRIPTION", "Get the latest release in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LATEST_RELEASE_USER_TITLE", "Get latest release"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			release, resp, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get latest release: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get latest release", resp, body), nil
+			}
+
+			r, err := json.Marshal(release)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release_by_tag",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_BY_TAG_DESCRIPTION", "Get a specific release by its tag name in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_BY_TAG_USER_TITLE", "Get a release by tag name"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name (e.g., 'v1.0.0')"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			release, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get release by tag: %s", tag),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get release by tag", resp, body), nil
+			}
+
+			r, err := json.Marshal(release)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// releaseFields reads the fields of a release shared by create_release and update_release.
+// Only the fields present in the request are set.
+func releaseFields(request mcp.CallToolRequest, release *github.RepositoryRelease) (bool, error) {
+	provided := false
+	for _, field := range []struct {
+		name  string
+		value **string
+	}{
+		{"tag_name", &release.TagName},
+		{"target_commitish", &release.TargetCommitish},
+		{"name", &release.Name},
+		{"body", &release.Body},
+		{"make_latest", &release.MakeLatest},
+		{"discussion_category_name", &release.DiscussionCategoryName},
+	} {
+		v, ok, err := OptionalParamOK[string](request, field.name)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			*field.value = github.Ptr(v)
+			provided = true
+		}
+	}
+	for _, field := range []struct {
+		name  string
+		value **bool
+	}{
+		{"draft", &release.Draft},
+		{"prerelease", &release.Prerelease},
+	} {
+		v, ok, err := OptionalParamOK[bool](request, field.name)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			*field.value = github.Ptr(v)
+			provided = true
+		}
+	}
+	if release.MakeLatest != nil {
+		switch *release.MakeLatest {
+		case "true", "false", "legacy":
+		default:
+			return false, fmt.Errorf("make_latest must be true, false or legacy")
+		}
+	}
+	return provided, nil
+}
+
+// releaseOptions are the options of create_release and update_release.
+func releaseOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("target_commitish",
+			mcp.Description("Branch or commit SHA the tag is created from, if it does not exist yet. Defaults to the default branch"),
+		),
+		mcp.WithString("name",
+			mcp.Description("Name of the release"),
+		),
+		mcp.WithString("body",
+			mcp.Description("Description of the release, in Markdown"),
+		),
+		mcp.WithBoolean("draft",
+			mcp.Description("Whether the release is an unpublished draft"),
+		),
+		mcp.WithBoolean("prerelease",
+			mcp.Description("Whether the release is a prerelease"),
+		),
+		mcp.WithString("make_latest",
+			mcp.Description("Whether the release is marked as the latest release. legacy decides by creation date and semantic version"),
+			mcp.Enum("true", "false", "legacy"),
+		),
+		mcp.WithString("discussion_category_name",
+			mcp.Description("Category of a discussion to create and link to the release"),
+		),
+	}
+}
+
+// CreateRelease creates a tool to create a release in a GitHub repository.
+func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_CREATE_RELEASE_DESCRIPTION", "Create a release in a GitHub repository. The tag is created from target_commitish if it does not exist yet")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_CREATE_RELEASE_USER_TITLE", "Create release"),
+			ReadOnlyHint: ToBoolPtr(false),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithString("tag_name",
+			mcp.Required(),
+			mcp.Description("Tag of the release (e.g., 'v1.0.0')"),
+		),
+		mcp.WithBoolean("generate_release_notes",
+			mcp.Description("Generate the name and body of the release from the changes since the previous release. A provided body is put before the generated notes"),
+		),
+	}
+	return mcp.NewTool("create_release", append(options, releaseOptions()...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := RequiredParam[string](request, "tag_name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release := &github.RepositoryRelease{}
+			if _, err := releaseFields(request, release); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			generateNotes, err := OptionalParam[bool](request, "generate_release_notes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if generateNotes {
+				release.GenerateReleaseNotes = github.Ptr(true)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create release: %s", release.GetTagName()),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateRelease creates a tool to update a release in a GitHub repository.
+func UpdateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_UPDATE_RELEASE_DESCRIPTION", "Update a release in a GitHub repository, e.g. to publish a draft. Only the provided fields are changed")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_UPDATE_RELEASE_USER_TITLE", "Update release"),
+			ReadOnlyHint: ToBoolPtr(false),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithNumber("release_id",
+			mcp.Required(),
+			mcp.Description("ID of the release"),
+		),
+		mcp.WithString("tag_name",
+			mcp.Description("New tag of the release"),
+		),
+	}
+	return mcp.NewTool("update_release", append(options, releaseOptions()...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release := &github.RepositoryRelease{}
+			provided, err := releaseFields(request, release)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !provided {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Repositories.EditRelease(ctx, owner, repo, int64(releaseID), release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update release: %d", releaseID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteRelease creates a tool to delete a release in a GitHub repository.
+func DeleteRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_release",
+			mcp.WithDescription(t("TOOL_DELETE_RELEASE_DESCRIPTION", "Delete a release in a GitHub repository, with its assets. The tag of the release is kept")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_RELEASE_USER_TITLE", "Delete release"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("ID of the release"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteRelease(ctx, owner, repo, int64(releaseID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete release: %d", releaseID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("release %d deleted", releaseID)), nil
+		}
+}
+
+// GenerateReleaseNotes creates a tool to generate the notes of a release without creating it.
+func GenerateReleaseNotes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("generate_release_notes",
+			mcp.WithDescription(t("TOOL_GENERATE_RELEASE_NOTES_DESCRIPTION", "Generate the name and Markdown notes of a release from the pull requests and contributors since the previous release, without creating it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GENERATE_RELEASE_NOTES_USER_TITLE", "Generate release notes"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag_name",
+				mcp.Required(),
+				mcp.Description("Tag of the release, existing or not (e.g., 'v1.0.0')"),
+			),
+			mcp.WithString("target_commitish",
+				mcp.Description("Branch or commit SHA the tag would be created from, if it does not exist yet"),
+			),
+			mcp.WithString("previous_tag_name",
+				mcp.Description("Tag of the previous release. Defaults to the latest release"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tagName, err := RequiredParam[string](request, "tag_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetCommitish, err := OptionalParam[string](request, "target_commitish")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			previousTagName, err := OptionalParam[string](request, "previous_tag_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.GenerateNotesOptions{TagName: tagName}
+			if targetCommitish != "" {
+				opts.TargetCommitish = github.Ptr(targetCommitish)
+			}
+			if previousTagName != "" {
+				opts.PreviousTagName = github.Ptr(previousTagName)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			notes, resp, err := client.Repositories.GenerateReleaseNotes(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to generate release notes: %s", tagName),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(notes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UploadReleaseAsset creates a tool to upload an asset to a release in a GitHub repository.
+func UploadReleaseAsset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("upload_release_asset",
+			mcp.WithDescription(t("TOOL_UPLOAD_RELEASE_ASSET_DESCRIPTION", "Upload a file as an asset of a release in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPLOAD_RELEASE_ASSET_USER_TITLE", "Upload release asset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("ID of the release"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("File name of the asset (e.g., 'app-linux-amd64.tar.gz')"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Content of the asset"),
+			),
+			mcp.WithString("encoding",
+				mcp.Description("Encoding of content, base64 for binary files. Default is utf-8"),
+				mcp.Enum("utf-8", "base64"),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("Media type of the asset. Defaults to the type of the file name's extension"),
+			),
+			mcp.WithString("label",
+				mcp.Description("Label shown instead of the file name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			encoding, err := OptionalParam[string](request, "encoding")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentType, err := OptionalParam[string](request, "content_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			data := []byte(content)
+			if encoding == "base64" {
+				data, err = base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("content is not valid base64: %s", err)), nil
+				}
+			}
+			if contentType == "" {
+				contentType = mime.TypeByExtension(path.Ext(name))
+			}
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Assets are uploaded to the upload URL of the API host, which the client resolves
+			query := url.Values{"name": {name}}
+			if label != "" {
+				query.Set("label", label)
+			}
+			u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?%s", owner, repo, releaseID, query.Encode())
+			req, err := client.NewUploadRequest(u, bytes.NewReader(data), int64(len(data)), contentType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create upload request: %w", err)
+			}
+
+			asset := new(github.ReleaseAsset)
+			resp, err := client.Do(ctx, req, asset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to upload release asset: %s", name),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(asset)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -486,6 +487,202 @@ func Test_RenameRepository(t *testing.T) {
 	}
 }
 
+func Test_UpdateRepository(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "topics")
+	assert.Contains(t, tool.InputSchema.Properties, "archived")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRepo := &github.Repository{
+		HTMLURL:          github.Ptr("https://github.com/owner/repo"),
+		Description:      github.Ptr("Old"),
+		Topics:           []string{"go"},
+		Visibility:       github.Ptr("public"),
+		DefaultBranch:    github.Ptr("main"),
+		AllowMergeCommit: github.Ptr(true),
+		HasWiki:          github.Ptr(true),
+	}
+	editedRepo := *mockRepo
+	editedRepo.Description = github.Ptr("New")
+	editedRepo.HasWiki = github.Ptr(false)
+	archivedRepo := editedRepo
+	archivedRepo.Archived = github.Ptr(true)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectedChanges map[string]SettingChange
+		expectedErrMsg  string
+	}{
+		{
+			name: "update settings, topics and archive",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					func() http.HandlerFunc {
+						edits := []http.HandlerFunc{
+							expectRequestBody(t, map[string]any{"description": "New", "has_wiki": false}).andThen(
+								mockResponse(t, http.StatusOK, &editedRepo),
+							),
+							expectRequestBody(t, map[string]any{"archived": true}).andThen(
+								mockResponse(t, http.StatusOK, &archivedRepo),
+							),
+						}
+						return func(w http.ResponseWriter, r *http.Request) {
+							require.NotEmpty(t, edits, "unexpected repository edit")
+							edit := edits[0]
+							edits = edits[1:]
+							edit(w, r)
+						}
+					}(),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"names": []any{"go", "mcp"}}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{"go", "mcp"}}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"description": "New",
+				"has_wiki":    false,
+				"topics":      []interface{}{"go", "mcp"},
+				"archived":    true,
+			},
+			expectedChanges: map[string]SettingChange{
+				"description": {Before: "Old", After: "New"},
+				"has_wiki":    {Before: true, After: false},
+				"topics":      {Before: []any{"go"}, After: []any{"go", "mcp"}},
+				"archived":    {Before: false, After: true},
+			},
+		},
+		{
+			name: "unchanged setting",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"default_branch": "main"}).andThen(
+						mockResponse(t, http.StatusOK, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"default_branch": "main",
+			},
+			expectedChanges: map[string]SettingChange{},
+		},
+		{
+			name:         "no update parameters",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "internal",
+			},
+			expectedErrMsg: "failed to update repository",
+		},
+	}
+
+	t.Run("reports settings changed before a failure", func(t *testing.T) {
+		archived := *mockRepo
+		archived.Archived = github.Ptr(true)
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposByOwnerByRepo,
+				&archived,
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposByOwnerByRepo,
+				func() http.HandlerFunc {
+					edits := []http.HandlerFunc{
+						expectRequestBody(t, map[string]any{"archived": false}).andThen(
+							mockResponse(t, http.StatusOK, mockRepo),
+						),
+						mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+					}
+					return func(w http.ResponseWriter, r *http.Request) {
+						require.NotEmpty(t, edits, "unexpected repository edit")
+						edit := edits[0]
+						edits = edits[1:]
+						edit(w, r)
+					}
+				}(),
+			),
+		))
+		_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":      "owner",
+			"repo":       "repo",
+			"visibility": "internal",
+			"archived":   false,
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "failed to update repository")
+		assert.Equal(t, `These settings were changed before the failure and were not reverted: {"archived":{"before":true,"after":false}}`, result.Content[1].(mcp.TextContent).Text)
+	})
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var updated UpdateRepositoryResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &updated))
+			assert.Equal(t, "https://github.com/owner/repo", updated.URL)
+			assert.Equal(t, tc.expectedChanges, updated.Changes)
+		})
+	}
+}
+
 func Test_CreateBranch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -824,6 +1021,8 @@ func Test_ListCommits(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "author")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "fetch_all")
+	assert.Contains(t, tool.InputSchema.Properties, "max_items")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock commits for success case
@@ -970,6 +1169,32 @@ func Test_ListCommits(t *testing.T) {
 			expectError:     false,
 			expectedCommits: mockCommits,
 		},
+		{
+			name: "successful commits fetch with fetch_all",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+						page := r.URL.Query().Get("page")
+						if page == "1" {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits?page=2&per_page=100>; rel="next", <https://api.github.com/repos/owner/repo/commits?page=2&per_page=100>; rel="last"`)
+							mockResponse(t, http.StatusOK, mockCommits[:1])(w, r)
+							return
+						}
+						assert.Equal(t, "2", page)
+						mockResponse(t, http.StatusOK, mockCommits[1:])(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"fetch_all": true,
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+		},
 		{
 			name: "commits fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -2288,6 +2513,226 @@ func Test_GetTag(t *testing.T) {
 	}
 }
 
+func Test_CreateTag(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "target")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+
+	resolveTarget := func(expectedPath string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposCommitsByOwnerByRepoByRef,
+			expectPath(t, expectedPath).andThen(
+				mockResponse(t, http.StatusOK, "commit-sha"),
+			),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectedResult CreatedTag
+		expectedErrMsg string
+	}{
+		{
+			name: "lightweight tag of the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				resolveTarget("/repos/owner/repo/commits/HEAD"),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"ref": "refs/tags/v1.0.0", "sha": "commit-sha"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/v1.0.0")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+			},
+			expectedResult: CreatedTag{Tag: "v1.0.0", Ref: "refs/tags/v1.0.0", CommitSHA: "commit-sha"},
+		},
+		{
+			name: "annotated tag with tagger",
+			mockedClient: mock.NewMockedHTTPClient(
+				resolveTarget("/repos/owner/repo/commits/release"),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag":     "v1.0.0",
+						"message": "Version 1.0.0",
+						"object":  "commit-sha",
+						"type":    "commit",
+						"tagger":  map[string]any{"name": "Release Bot", "email": "bot@example.com"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tag{SHA: github.Ptr("tag-sha")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"ref": "refs/tags/v1.0.0", "sha": "tag-sha"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/v1.0.0")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"tag":          "v1.0.0",
+				"target":       "release",
+				"message":      "Version 1.0.0",
+				"tagger_name":  "Release Bot",
+				"tagger_email": "bot@example.com",
+			},
+			expectedResult: CreatedTag{Tag: "v1.0.0", Ref: "refs/tags/v1.0.0", CommitSHA: "commit-sha", TagSHA: "tag-sha", Annotated: true},
+		},
+		{
+			name:         "tagger without message",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"tag":          "v1.0.0",
+				"tagger_name":  "Release Bot",
+				"tagger_email": "bot@example.com",
+			},
+			expectedErrMsg: "a message is required for a tag with a tagger",
+		},
+		{
+			name:         "tagger name without email",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tag":         "v1.0.0",
+				"message":     "Version 1.0.0",
+				"tagger_name": "Release Bot",
+			},
+			expectedErrMsg: "tagger_name and tagger_email must be provided together",
+		},
+		{
+			name: "tag already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				resolveTarget("/repos/owner/repo/commits/HEAD"),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reference already exists"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+			},
+			expectedErrMsg: "failed to create tag: v1.0.0",
+		},
+		{
+			name: "unknown target",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: missing"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"tag":    "v1.0.0",
+				"target": "missing",
+			},
+			expectedErrMsg: "failed to resolve target: missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var created CreatedTag
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &created))
+			assert.Equal(t, tc.expectedResult, created)
+		})
+	}
+}
+
+func Test_DeleteTag(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectedErrMsg string
+	}{
+		{
+			name: "successful deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/refs/tags/v1.0.0").andThen(
+						mockResponse(t, http.StatusNoContent, ""),
+					),
+				),
+			),
+		},
+		{
+			name: "tag not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reference does not exist"}`),
+				),
+			),
+			expectedErrMsg: "failed to delete tag: v1.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, "tag v1.0.0 deleted", getTextResult(t, result).Text)
+		})
+	}
+}
+
 func Test_ListReleases(t *testing.T) {
 	mockClient := github.NewClient(nil)
 	tool, _ := ListReleases(stubGetClientFn(mockClient), translations.NullTranslationHelper)
@@ -2968,3 +3413,740 @@ func Test_resolveGitReference(t *testing.T) {
 		})
 	}
 }
+
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include_patches")
+	assert.Contains(t, tool.InputSchema.Properties, "max_patch_bytes")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	mockComparison := &github.CommitsComparison{
+		Status:          github.Ptr("ahead"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(0),
+		TotalCommits:    github.Ptr(2),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/compare/v1.4.0...main"),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base-sha")},
+		Commits: []*github.RepositoryCommit{
+			{SHA: github.Ptr("abc123"), Commit: &github.Commit{Message: github.Ptr("Add feature")}},
+			{SHA: github.Ptr("def456"), Commit: &github.Commit{Message: github.Ptr("Fix bug")}},
+		},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(3), Deletions: github.Ptr(1), Changes: github.Ptr(4), Patch: github.Ptr("@@ -1 +1,3 @@")},
+			{Filename: github.Ptr("docs/new.md"), PreviousFilename: github.Ptr("docs/old.md"), Status: github.Ptr("renamed"), Additions: github.Ptr(10), Changes: github.Ptr(10), Patch: github.Ptr(strings.Repeat("+line\n", 10))},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		verify         func(t *testing.T, comparison MinimalComparison)
+	}{
+		{
+			name: "successful comparison without patches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/v1.4.0...main").andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.4.0",
+				"head":  "main",
+			},
+			verify: func(t *testing.T, comparison MinimalComparison) {
+				assert.Equal(t, "ahead", comparison.Status)
+				assert.Equal(t, 2, comparison.AheadBy)
+				assert.Equal(t, 0, comparison.BehindBy)
+				assert.Equal(t, "base-sha", comparison.MergeBaseCommit)
+				assert.Equal(t, MinimalCommitStats{Additions: 13, Deletions: 1, Total: 14}, comparison.Stats)
+				require.Len(t, comparison.Commits, 2)
+				assert.Equal(t, "abc123", comparison.Commits[0].SHA)
+				assert.Equal(t, "Add feature", comparison.Commits[0].Commit.Message)
+				require.Len(t, comparison.Files, 2)
+				assert.Equal(t, "docs/old.md", comparison.Files[1].PreviousFilename)
+				assert.Empty(t, comparison.Files[0].Patch)
+				assert.False(t, comparison.Files[0].PatchOmitted)
+			},
+		},
+		{
+			name: "patches within the budget",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCompareByOwnerByRepoByBasehead, mockComparison),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"base":            "v1.4.0",
+				"head":            "main",
+				"include_patches": true,
+				"max_patch_bytes": float64(20),
+			},
+			verify: func(t *testing.T, comparison MinimalComparison) {
+				assert.Equal(t, "@@ -1 +1,3 @@", comparison.Files[0].Patch)
+				assert.False(t, comparison.Files[0].PatchOmitted)
+				assert.Empty(t, comparison.Files[1].Patch)
+				assert.True(t, comparison.Files[1].PatchOmitted)
+			},
+		},
+		{
+			name: "unknown ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v0.0.0",
+				"head":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare v0.0.0...main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			var comparison MinimalComparison
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &comparison))
+			tc.verify(t, comparison)
+		})
+	}
+}
+
+func Test_CommitChanges(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CommitChanges(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "commit_changes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "changes")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_parent_sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "message", "changes"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("parent-sha")},
+	}
+	mockParent := &github.Commit{
+		SHA:  github.Ptr("parent-sha"),
+		Tree: &github.Tree{SHA: github.Ptr("base-tree-sha")},
+	}
+	treesHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var tree *github.Tree
+		switch r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:] {
+		case "base-tree-sha":
+			tree = &github.Tree{SHA: github.Ptr("base-tree-sha"), Entries: []*github.TreeEntry{
+				{Path: github.Ptr("README.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("readme-sha")},
+				{Path: github.Ptr("build.sh"), Mode: github.Ptr("100755"), Type: github.Ptr("blob"), SHA: github.Ptr("build-sha")},
+				{Path: github.Ptr("src"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("src-sha")},
+			}}
+		case "src-sha":
+			tree = &github.Tree{SHA: github.Ptr("src-sha"), Entries: []*github.TreeEntry{
+				{Path: github.Ptr("main.go"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("main-sha")},
+			}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(mock.MustMarshal(tree))
+	})
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]any
+		updateRefStatus int
+		expectedTree    []map[string]any
+		expectedErrMsg  string
+	}{
+		{
+			name: "adds, updates, deletes and renames files in one commit",
+			requestArgs: map[string]any{
+				"changes": []any{
+					map[string]any{"path": "README.md", "content": "# Updated"},
+					map[string]any{"path": "logo.png", "content": "iVBORw0KGgo=", "encoding": "base64"},
+					map[string]any{"path": "src/main.go", "action": "delete"},
+					map[string]any{"path": "scripts/build.sh", "action": "rename", "previous_path": "build.sh"},
+				},
+				"expected_parent_sha": "parent-sha",
+			},
+			expectedTree: []map[string]any{
+				{"path": "README.md", "mode": "100644", "type": "blob", "content": "# Updated"},
+				{"path": "logo.png", "mode": "100644", "type": "blob", "sha": "blob-sha"},
+				{"path": "src/main.go", "mode": "100644", "type": "blob", "sha": nil},
+				{"path": "build.sh", "mode": "100755", "type": "blob", "sha": nil},
+				{"path": "scripts/build.sh", "mode": "100755", "type": "blob", "sha": "build-sha"},
+			},
+		},
+		{
+			name: "makes a file executable without changing its content",
+			requestArgs: map[string]any{
+				"changes": []any{map[string]any{"path": "README.md", "executable": true}},
+			},
+			expectedTree: []map[string]any{
+				{"path": "README.md", "mode": "100755", "type": "blob", "sha": "readme-sha"},
+			},
+		},
+		{
+			name: "branch moved since the files were read",
+			requestArgs: map[string]any{
+				"changes":             []any{map[string]any{"path": "README.md", "content": "# Updated"}},
+				"expected_parent_sha": "stale-sha",
+			},
+			expectedErrMsg: "branch main has moved to parent-sha, expected stale-sha",
+		},
+		{
+			name: "branch moved while committing",
+			requestArgs: map[string]any{
+				"changes": []any{map[string]any{"path": "README.md", "content": "# Updated"}},
+			},
+			updateRefStatus: http.StatusUnprocessableEntity,
+			expectedErrMsg:  "branch main has moved while committing",
+		},
+		{
+			name: "delete of a missing file",
+			requestArgs: map[string]any{
+				"changes": []any{map[string]any{"path": "src/missing.go", "action": "delete"}},
+			},
+			expectedErrMsg: "cannot delete src/missing.go, the file does not exist",
+		},
+		{
+			name: "delete of a directory",
+			requestArgs: map[string]any{
+				"changes": []any{map[string]any{"path": "src", "action": "delete"}},
+			},
+			expectedErrMsg: "src is not a file",
+		},
+		{
+			name: "rename onto an existing file",
+			requestArgs: map[string]any{
+				"changes": []any{map[string]any{"path": "README.md", "action": "rename", "previous_path": "build.sh"}},
+			},
+			expectedErrMsg: "cannot rename build.sh to README.md, the file already exists",
+		},
+		{
+			name: "update then rename of the same file",
+			requestArgs: map[string]any{
+				"changes": []any{
+					map[string]any{"path": "build.sh", "content": "echo new"},
+					map[string]any{"path": "run.sh", "action": "rename", "previous_path": "build.sh"},
+				},
+			},
+			expectedErrMsg: "changes[1]: build.sh is changed more than once",
+		},
+		{
+			name: "rename then update of the target",
+			requestArgs: map[string]any{
+				"changes": []any{
+					map[string]any{"path": "run.sh", "action": "rename", "previous_path": "build.sh"},
+					map[string]any{"path": "run.sh", "content": "echo new"},
+				},
+			},
+			expectedErrMsg: "changes[1]: run.sh is changed more than once",
+		},
+		{
+			name: "delete then recreate of the same file",
+			requestArgs: map[string]any{
+				"changes": []any{
+					map[string]any{"path": "README.md", "action": "delete"},
+					map[string]any{"path": "README.md", "content": "# New"},
+				},
+			},
+			expectedErrMsg: "changes[1]: README.md is changed more than once",
+		},
+		{
+			name: "new file without content",
+			requestArgs: map[string]any{
+				"changes": []any{map[string]any{"path": "new.sh", "executable": true}},
+			},
+			expectedErrMsg: "content is required to create new.sh",
+		},
+		{
+			name: "invalid base64 content",
+			requestArgs: map[string]any{
+				"changes": []any{map[string]any{"path": "logo.png", "content": "not base64!", "encoding": "base64"}},
+			},
+			expectedErrMsg: "content of logo.png is not valid base64",
+		},
+		{
+			name: "unknown action",
+			requestArgs: map[string]any{
+				"changes": []any{map[string]any{"path": "README.md", "action": "move"}},
+			},
+			expectedErrMsg: `unknown action "move"`,
+		},
+		{
+			name:           "no changes",
+			requestArgs:    map[string]any{"changes": []any{}},
+			expectedErrMsg: "changes must be a non-empty array of objects",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var createdTree []map[string]any
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, mockParent),
+				mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, treesHandler),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"content": "iVBORw0KGgo=", "encoding": "base64"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("blob-sha")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body struct {
+							BaseTree string           `json:"base_tree"`
+							Tree     []map[string]any `json:"tree"`
+						}
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, "base-tree-sha", body.BaseTree)
+						createdTree = body.Tree
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write(mock.MustMarshal(&github.Tree{SHA: github.Ptr("new-tree-sha")}))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockResponse(t, http.StatusCreated, &github.Commit{
+						SHA:     github.Ptr("new-sha"),
+						HTMLURL: github.Ptr("https://github.com/owner/repo/commit/new-sha"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body map[string]any
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, map[string]any{"sha": "new-sha", "force": false}, body)
+						if tc.updateRefStatus != 0 {
+							w.WriteHeader(tc.updateRefStatus)
+							_, _ = w.Write([]byte(`{"message": "Update is not a fast forward"}`))
+							return
+						}
+						_, _ = w.Write(mock.MustMarshal(mockRef))
+					}),
+				),
+			))
+			_, handler := CommitChanges(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "branch": "main", "message": "Update files"}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			assert.Equal(t, tc.expectedTree, createdTree)
+			var commit CommitChangesResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &commit))
+			assert.Equal(t, CommitChangesResult{
+				SHA:       "new-sha",
+				ParentSHA: "parent-sha",
+				Branch:    "main",
+				HTMLURL:   "https://github.com/owner/repo/commit/new-sha",
+				Changes:   len(tc.requestArgs["changes"].([]any)),
+			}, commit)
+		})
+	}
+}
+
+func Test_CreateRelease(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "generate_release_notes")
+	assert.Contains(t, tool.InputSchema.Properties, "make_latest")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag_name"})
+
+	mockRelease := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(1)),
+		TagName: github.Ptr("v1.0.0"),
+		Draft:   github.Ptr(true),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "create draft with generated notes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":               "v1.0.0",
+						"target_commitish":       "main",
+						"draft":                  true,
+						"generate_release_notes": true,
+						"make_latest":            "true",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"tag_name":               "v1.0.0",
+				"target_commitish":       "main",
+				"draft":                  true,
+				"generate_release_notes": true,
+				"make_latest":            "true",
+			},
+		},
+		{
+			name:         "invalid make_latest",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tag_name":    "v1.0.0",
+				"make_latest": "yes",
+			},
+			expectedErrMsg: "make_latest must be true, false or legacy",
+		},
+		{
+			name: "tag already has a release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag_name": "v1.0.0",
+			},
+			expectedErrMsg: "failed to create release: v1.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var release github.RepositoryRelease
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &release))
+			assert.Equal(t, *mockRelease.ID, *release.ID)
+			assert.Equal(t, *mockRelease.HTMLURL, *release.HTMLURL)
+		})
+	}
+}
+
+func Test_UpdateRelease(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+
+	mockRelease := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(1)),
+		TagName: github.Ptr("v1.0.0"),
+		Draft:   github.Ptr(false),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "publish draft",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					expectPath(t, "/repos/owner/repo/releases/1").andThen(
+						expectRequestBody(t, map[string]any{"draft": false, "body": "Notes"}).andThen(
+							mockResponse(t, http.StatusOK, mockRelease),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+				"draft":      false,
+				"body":       "Notes",
+			},
+		},
+		{
+			name:         "no update parameters",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+			},
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(2),
+				"name":       "Renamed",
+			},
+			expectedErrMsg: "failed to update release: 2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var release github.RepositoryRelease
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &release))
+			assert.False(t, release.GetDraft())
+		})
+	}
+}
+
+func Test_DeleteRelease(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposReleasesByOwnerByRepoByReleaseId,
+			expectPath(t, "/repos/owner/repo/releases/1").andThen(
+				mockResponse(t, http.StatusNoContent, ""),
+			),
+		),
+	))
+	_, handler := DeleteRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"release_id": float64(1),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "release 1 deleted", getTextResult(t, result).Text)
+}
+
+func Test_GenerateReleaseNotes(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GenerateReleaseNotes(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "generate_release_notes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposReleasesGenerateNotesByOwnerByRepo,
+			expectRequestBody(t, map[string]any{"tag_name": "v1.1.0", "previous_tag_name": "v1.0.0"}).andThen(
+				mockResponse(t, http.StatusOK, &github.RepositoryReleaseNotes{Name: "v1.1.0", Body: "## What's Changed"}),
+			),
+		),
+	))
+	_, handler := GenerateReleaseNotes(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":             "owner",
+		"repo":              "repo",
+		"tag_name":          "v1.1.0",
+		"previous_tag_name": "v1.0.0",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var notes github.RepositoryReleaseNotes
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &notes))
+	assert.Equal(t, "## What's Changed", notes.Body)
+}
+
+func Test_UploadReleaseAsset(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UploadReleaseAsset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "upload_release_asset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id", "name", "content"})
+
+	tests := []struct {
+		name                string
+		requestArgs         map[string]interface{}
+		expectedQuery       map[string]string
+		expectedContentType string
+		expectedBody        string
+		expectedErrMsg      string
+	}{
+		{
+			name: "binary asset",
+			requestArgs: map[string]interface{}{
+				"content":  base64.StdEncoding.EncodeToString([]byte{0x1f, 0x8b, 0x08}),
+				"encoding": "base64",
+				"name":     "app.tar.gz",
+				"label":    "Linux build",
+			},
+			expectedQuery:       map[string]string{"name": "app.tar.gz", "label": "Linux build"},
+			expectedContentType: "application/gzip",
+			expectedBody:        "\x1f\x8b\x08",
+		},
+		{
+			name: "text asset with explicit content type",
+			requestArgs: map[string]interface{}{
+				"content":      "sha256  app.tar.gz",
+				"name":         "checksums",
+				"content_type": "text/plain",
+			},
+			expectedQuery:       map[string]string{"name": "checksums"},
+			expectedContentType: "text/plain",
+			expectedBody:        "sha256  app.tar.gz",
+		},
+		{
+			name: "invalid base64",
+			requestArgs: map[string]interface{}{
+				"content":  "not base64!",
+				"encoding": "base64",
+				"name":     "app.bin",
+			},
+			expectedErrMsg: "content is not valid base64",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesAssetsByOwnerByRepoByReleaseId,
+					expectQueryParams(t, tc.expectedQuery).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							assert.Equal(t, "uploads.github.com", r.Host)
+							assert.Equal(t, tc.expectedContentType, r.Header.Get("Content-Type"))
+							body, err := io.ReadAll(r.Body)
+							require.NoError(t, err)
+							assert.Equal(t, tc.expectedBody, string(body))
+							w.WriteHeader(http.StatusCreated)
+							_, _ = w.Write(mock.MustMarshal(&github.ReleaseAsset{
+								ID:   github.Ptr(int64(7)),
+								Name: github.Ptr(tc.expectedQuery["name"]),
+								Size: github.Ptr(len(body)),
+							}))
+						}),
+					),
+				),
+			))
+			_, handler := UploadReleaseAsset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{"owner": "owner", "repo": "repo", "release_id": float64(1)}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var asset github.ReleaseAsset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &asset))
+			assert.Equal(t, int64(7), asset.GetID())
+			assert.Equal(t, len(tc.expectedBody), asset.GetSize())
+		})
+	}
+}
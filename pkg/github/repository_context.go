@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositoryRef identifies a repository.
+type RepositoryRef struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+}
+
+// Sources of the repository context.
+const (
+	RepositoryContextSession   = "session"
+	RepositoryContextWorkspace = "workspace"
+)
+
+// RepositoryContext holds the default repository of each client session, so that tools can be
+// called without repeating owner and repo. Sessions are identified by their MCP session ID.
+// Sessions without a default fall back to the repositories found in the workspace of the client.
+type RepositoryContext struct {
+	mu        sync.RWMutex
+	sessions  map[string]RepositoryRef
+	workspace []WorkspaceRepository
+}
+
+// NewRepositoryContext creates an empty RepositoryContext.
+func NewRepositoryContext() *RepositoryContext {
+	return &RepositoryContext{sessions: make(map[string]RepositoryRef)}
+}
+
+// sessionID returns the ID of the client session of the request, or an empty string outside of a session.
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// Set sets the default repository of the session of ctx.
+func (c *RepositoryContext) Set(ctx context.Context, ref RepositoryRef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions[sessionID(ctx)] = ref
+}
+
+// Get returns the default repository of the session of ctx, if one is set or found in the workspace.
+func (c *RepositoryContext) Get(ctx context.Context) (RepositoryRef, bool) {
+	ref, _, ok := c.lookup(ctx)
+	return ref, ok
+}
+
+// lookup returns the default repository of the session of ctx and where it comes from.
+func (c *RepositoryContext) lookup(ctx context.Context) (RepositoryRef, string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if ref, ok := c.sessions[sessionID(ctx)]; ok {
+		return ref, RepositoryContextSession, true
+	}
+	if len(c.workspace) > 0 {
+		return RepositoryRef{Owner: c.workspace[0].Owner, Repo: c.workspace[0].Repo}, RepositoryContextWorkspace, true
+	}
+	return RepositoryRef{}, "", false
+}
+
+// SetWorkspaceRepositories replaces the repositories found in the workspace of the client.
+func (c *RepositoryContext) SetWorkspaceRepositories(repos []WorkspaceRepository) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.workspace = repos
+}
+
+// WorkspaceRepositories returns the repositories found in the workspace of the client.
+func (c *RepositoryContext) WorkspaceRepositories() []WorkspaceRepository {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.workspace
+}
+
+// takesRepository reports whether a tool requires both the owner and repo parameters.
+func takesRepository(tool mcp.Tool) bool {
+	required := make(map[string]bool, len(tool.InputSchema.Required))
+	for _, name := range tool.InputSchema.Required {
+		required[name] = true
+	}
+	return required["owner"] && required["repo"]
+}
+
+// RepositoryContextTool returns a decorator that makes the owner and repo parameters of tools
+// optional, defaulting them to the repository context of the session. Tools that do not
+// require both parameters are returned unchanged.
+func RepositoryContextTool(repoContext *RepositoryContext) func(server.ServerTool) server.ServerTool {
+	return func(st server.ServerTool) server.ServerTool {
+		if !takesRepository(st.Tool) {
+			return st
+		}
+		tool := st.Tool
+		tool.InputSchema.Properties = cloneProperties(tool.InputSchema.Properties)
+		for _, name := range []string{"owner", "repo"} {
+			if property, ok := tool.InputSchema.Properties[name].(map[string]any); ok {
+				property = cloneProperties(property)
+				description, _ := property["description"].(string)
+				property["description"] = description + ". Defaults to the repository context of the session or workspace"
+				tool.InputSchema.Properties[name] = property
+			}
+		}
+		required := make([]string, 0, len(tool.InputSchema.Required))
+		for _, name := range tool.InputSchema.Required {
+			if name != "owner" && name != "repo" {
+				required = append(required, name)
+			}
+		}
+		tool.InputSchema.Required = required
+
+		handler := st.Handler
+		return server.ServerTool{
+			Tool: tool,
+			Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				args := request.GetArguments()
+				owner, _ := args["owner"].(string)
+				repo, _ := args["repo"].(string)
+				if owner != "" && repo != "" {
+					return handler(ctx, request)
+				}
+
+				ref, ok := repoContext.Get(ctx)
+				switch {
+				case !ok:
+					return mcp.NewToolResultError(fmt.Sprintf("missing required parameter: %s, pass it or set a default with set_repository_context", missingRepositoryParam(owner))), nil
+				case owner != "" && !strings.EqualFold(owner, ref.Owner):
+					// The context repository only applies to its own owner
+					return mcp.NewToolResultError("missing required parameter: repo"), nil
+				}
+
+				defaulted := make(map[string]any, len(args)+2)
+				for k, v := range args {
+					defaulted[k] = v
+				}
+				if owner == "" {
+					defaulted["owner"] = ref.Owner
+				}
+				if repo == "" {
+					defaulted["repo"] = ref.Repo
+				}
+				request.Params.Arguments = defaulted
+				return handler(ctx, request)
+			},
+		}
+	}
+}
+
+func missingRepositoryParam(owner string) string {
+	if owner == "" {
+		return "owner"
+	}
+	return "repo"
+}
+
+// SetRepositoryContext creates a tool to set the default repository of the session.
+func SetRepositoryContext(getClient GetClientFn, repoContext *RepositoryContext, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repository_context",
+			mcp.WithDescription(t("TOOL_SET_REPOSITORY_CONTEXT_DESCRIPTION", "Set the default repository for this session. Tools that take owner and repo use it when they are omitted. Use this when working on a single repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title: t("TOOL_SET_REPOSITORY_CONTEXT_USER_TITLE", "Set repository context"),
+				// Not modifying GitHub data so no need to show a warning
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// Look the repository up so that typos are caught now and the canonical names are stored
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			ref := RepositoryRef{Owner: repository.GetOwner().GetLogin(), Repo: repository.GetName()}
+			repoContext.Set(ctx, ref)
+			return MarshalledTextResult(ref), nil
+		}
+}
+
+// repositoryContextResult is the result of get_repository_context.
+type repositoryContextResult struct {
+	RepositoryRef
+	Source string `json:"source"`
+}
+
+// GetRepositoryContext creates a tool to get the default repository of the session.
+func GetRepositoryContext(repoContext *RepositoryContext, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_context",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_CONTEXT_DESCRIPTION", "Get the default repository of this session that tools use when owner and repo are omitted, either set with set_repository_context or found in the workspace of the client")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_CONTEXT_USER_TITLE", "Get repository context"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ref, source, ok := repoContext.lookup(ctx)
+			if !ok {
+				return mcp.NewToolResultText("No repository context is set, use set_repository_context to set one"), nil
+			}
+			return MarshalledTextResult(repositoryContextResult{RepositoryRef: ref, Source: source}), nil
+		}
+}
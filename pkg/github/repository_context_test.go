@@ -0,0 +1,165 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoRepositoryTool returns a tool that requires owner and repo and echoes them.
+func echoRepositoryTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("get_issue",
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)}),
+			mcp.WithString("owner", mcp.Required(), mcp.Description("Repository owner")),
+			mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name")),
+			mcp.WithNumber("issue_number", mcp.Required()),
+		),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultText(owner + "/" + repo), nil
+		},
+	}
+}
+
+func Test_RepositoryContextTool(t *testing.T) {
+	t.Run("tools without owner and repo are unchanged", func(t *testing.T) {
+		tool := okTool("list_gists")
+		decorated := RepositoryContextTool(NewRepositoryContext())(tool)
+		assert.Equal(t, tool.Tool, decorated.Tool)
+	})
+
+	t.Run("owner and repo become optional", func(t *testing.T) {
+		decorated := RepositoryContextTool(NewRepositoryContext())(echoRepositoryTool())
+		assert.Equal(t, []string{"issue_number"}, decorated.Tool.InputSchema.Required)
+		owner := decorated.Tool.InputSchema.Properties["owner"].(map[string]any)
+		assert.Equal(t, "Repository owner. Defaults to the repository context of the session or workspace", owner["description"])
+
+		// The original tool is not modified
+		assert.Contains(t, echoRepositoryTool().Tool.InputSchema.Required, "owner")
+	})
+
+	repoContext := NewRepositoryContext()
+	tool := RepositoryContextTool(repoContext)(echoRepositoryTool())
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("without a context", func(t *testing.T) {
+		result := call(map[string]any{"issue_number": float64(1)})
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "missing required parameter: owner")
+		assert.Contains(t, getErrorResult(t, result).Text, "set_repository_context")
+	})
+
+	repoContext.Set(context.Background(), RepositoryRef{Owner: "octocat", Repo: "hello-world"})
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		expected string
+		errMsg   string
+	}{
+		{name: "both omitted", args: map[string]any{}, expected: "octocat/hello-world"},
+		{name: "explicit arguments win", args: map[string]any{"owner": "github", "repo": "docs"}, expected: "github/docs"},
+		{name: "repo in the context owner", args: map[string]any{"repo": "other"}, expected: "octocat/other"},
+		{name: "same owner without repo", args: map[string]any{"owner": "OctoCat"}, expected: "OctoCat/hello-world"},
+		{name: "other owner without repo", args: map[string]any{"owner": "github"}, errMsg: "missing required parameter: repo"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := call(tc.args)
+			if tc.errMsg != "" {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.errMsg, getErrorResult(t, result).Text)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expected, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_SetRepositoryContext(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepositoryContext(stubGetClientFn(mockClient), NewRepositoryContext(), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_repository_context", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	t.Run("stores the canonical names", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{
+				Name:  github.Ptr("Hello-World"),
+				Owner: &github.User{Login: github.Ptr("octocat")},
+			}),
+		))
+		repoContext := NewRepositoryContext()
+		_, handler := SetRepositoryContext(stubGetClientFn(client), repoContext, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "OctoCat", "repo": "hello-world"}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var ref RepositoryRef
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ref))
+		assert.Equal(t, RepositoryRef{Owner: "octocat", Repo: "Hello-World"}, ref)
+
+		stored, ok := repoContext.Get(context.Background())
+		require.True(t, ok)
+		assert.Equal(t, ref, stored)
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposByOwnerByRepo, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			})),
+		))
+		repoContext := NewRepositoryContext()
+		_, handler := SetRepositoryContext(stubGetClientFn(client), repoContext, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octocat", "repo": "typo"}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get repository octocat/typo")
+		_, ok := repoContext.Get(context.Background())
+		assert.False(t, ok)
+	})
+}
+
+func Test_GetRepositoryContext(t *testing.T) {
+	repoContext := NewRepositoryContext()
+	tool, handler := GetRepositoryContext(repoContext, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, "No repository context is set")
+
+	repoContext.Set(context.Background(), RepositoryRef{Owner: "octocat", Repo: "hello-world"})
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"owner":"octocat","repo":"hello-world","source":"session"}`, getTextResult(t, result).Text)
+}
@@ -0,0 +1,449 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultTreeMaxEntries is the number of tree entries returned when max_entries is not provided.
+	DefaultTreeMaxEntries = 1000
+	// MaxTreeEntries is the upper bound for max_entries.
+	MaxTreeEntries = 10000
+	// MaxTreeFetches is the number of trees fetched at most when the recursive listing of a tree
+	// is truncated by the API and its subtrees are walked one by one.
+	MaxTreeFetches = 100
+)
+
+// TreeEntry is an entry of a repository tree.
+type TreeEntry struct {
+	Path string `json:"path"`
+	// Type is "blob" for files, "tree" for directories and "commit" for submodules
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+	Size int    `json:"size,omitempty"`
+}
+
+// RepositoryTree is the result of get_repository_tree.
+type RepositoryTree struct {
+	SHA     string      `json:"sha"`
+	Ref     string      `json:"ref,omitempty"`
+	Path    string      `json:"path,omitempty"`
+	Entries []TreeEntry `json:"entries"`
+	// TotalMatches counts the matching entries found, including those beyond max_entries. It is a
+	// lower bound when the walk of a large tree stopped early
+	TotalMatches int `json:"total_matches"`
+	// Truncated is set when more entries matched than max_entries, or the walk of a large tree
+	// stopped at max_entries or MaxTreeFetches
+	Truncated bool `json:"truncated"`
+}
+
+// treeFilter selects the entries of a tree returned by get_repository_tree.
+type treeFilter struct {
+	pattern    *regexp.Regexp
+	basename   bool
+	extensions []string
+	entryType  string
+	maxDepth   int
+	minSize    int
+	maxSize    int
+}
+
+// matches reports whether an entry at the given depth below the starting path matches the filter.
+func (f treeFilter) matches(entry TreeEntry, depth int) bool {
+	if f.maxDepth > 0 && depth > f.maxDepth {
+		return false
+	}
+	if f.entryType != "" && entry.Type != f.entryType {
+		return false
+	}
+	// Extensions and sizes only apply to files
+	if (len(f.extensions) > 0 || f.minSize > 0 || f.maxSize > 0) && entry.Type != "blob" {
+		return false
+	}
+	if len(f.extensions) > 0 {
+		ext := strings.ToLower(path.Ext(entry.Path))
+		found := false
+		for _, e := range f.extensions {
+			if ext == e {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.minSize > 0 && entry.Size < f.minSize {
+		return false
+	}
+	if f.maxSize > 0 && entry.Size > f.maxSize {
+		return false
+	}
+	if f.pattern != nil {
+		target := entry.Path
+		if f.basename {
+			target = path.Base(entry.Path)
+		}
+		if !f.pattern.MatchString(target) {
+			return false
+		}
+	}
+	return true
+}
+
+// compileGlob converts a glob pattern to a regular expression. "*" and "?" match within a path
+// segment, "**" matches across segments and "{a,b}" matches alternatives.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	inGroup := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			i++
+			if i+1 < len(pattern) && pattern[i+1] == '/' {
+				// "**/" also matches no directories at all
+				i++
+				sb.WriteString("(?:.*/)?")
+			} else {
+				sb.WriteString(".*")
+			}
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '{' && !inGroup:
+			inGroup = true
+			sb.WriteString("(?:")
+		case c == '}' && inGroup:
+			inGroup = false
+			sb.WriteString(")")
+		case c == ',' && inGroup:
+			sb.WriteString("|")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if inGroup {
+		return nil, fmt.Errorf("invalid pattern %q: unclosed '{'", pattern)
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// treeWalker fetches the entries of a tree, walking subtrees one by one when the recursive
+// listing of a tree is truncated by the API.
+type treeWalker struct {
+	client   *github.Client
+	owner    string
+	repo     string
+	maxDepth int
+	// maxTrees is the number of trees fetched at most
+	maxTrees   int
+	treesCount int
+	// stopped is set when the walk ended before all entries were visited
+	stopped bool
+}
+
+// getTree fetches a tree, unless maxTrees trees were fetched already, in which case the walk stops.
+func (w *treeWalker) getTree(ctx context.Context, sha string, recursive bool) (*github.Tree, *github.Response, error) {
+	if w.treesCount >= w.maxTrees {
+		w.stopped = true
+		return nil, nil, nil
+	}
+	tree, resp, err := w.client.Git.GetTree(ctx, w.owner, w.repo, sha, recursive)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+	w.treesCount++
+	ReportProgress(ctx, float64(w.treesCount), 0, fmt.Sprintf("Fetched %d trees", w.treesCount))
+	return tree, nil, nil
+}
+
+// walk visits the entries below the tree with the given SHA, with paths prefixed by prefix and
+// depths starting at depth. visit returns false when it has no room for a matching entry, in
+// which case the walk stops instead of fetching further subtrees.
+func (w *treeWalker) walk(ctx context.Context, sha, prefix string, depth int, visit func(TreeEntry, int) bool) (*github.Response, error) {
+	// A single level is all that is needed at the depth limit
+	recursive := w.maxDepth == 0 || depth < w.maxDepth
+	tree, resp, err := w.getTree(ctx, sha, recursive)
+	if err != nil || w.stopped {
+		return resp, err
+	}
+
+	if !recursive || !tree.GetTruncated() {
+		// All entries are at hand, so they are all counted even if there is no room for them
+		for _, entry := range tree.Entries {
+			if !visit(newTreeEntry(entry, prefix), depth+strings.Count(entry.GetPath(), "/")) {
+				w.stopped = true
+			}
+		}
+		return nil, nil
+	}
+
+	// The recursive listing is incomplete, so list this level and walk the subtrees separately
+	tree, resp, err = w.getTree(ctx, sha, false)
+	if err != nil || w.stopped {
+		return resp, err
+	}
+	for _, entry := range tree.Entries {
+		if !visit(newTreeEntry(entry, prefix), depth) {
+			w.stopped = true
+			return nil, nil
+		}
+		if entry.GetType() == "tree" && (w.maxDepth == 0 || depth < w.maxDepth) {
+			if resp, err := w.walk(ctx, entry.GetSHA(), prefix+entry.GetPath()+"/", depth+1, visit); err != nil || w.stopped {
+				return resp, err
+			}
+		}
+	}
+	return nil, nil
+}
+
+func newTreeEntry(entry *github.TreeEntry, prefix string) TreeEntry {
+	return TreeEntry{
+		Path: prefix + entry.GetPath(),
+		Type: entry.GetType(),
+		SHA:  entry.GetSHA(),
+		Size: entry.GetSize(),
+	}
+}
+
+// treeReader looks up the entries of the trees of a repository by path, fetching each tree once.
+type treeReader struct {
+	client *github.Client
+	owner  string
+	repo   string
+	trees  map[string]*github.Tree
+}
+
+func newTreeReader(client *github.Client, owner, repo string) *treeReader {
+	return &treeReader{client: client, owner: owner, repo: repo, trees: make(map[string]*github.Tree)}
+}
+
+// entry returns the entry at path below the tree or commit with the given SHA, or nil if there
+// is none. Paths are looked up one segment at a time, so that truncation of large trees does
+// not matter.
+func (r *treeReader) entry(ctx context.Context, sha, entryPath string) (*github.TreeEntry, *github.Response, error) {
+	segments := strings.Split(strings.Trim(entryPath, "/"), "/")
+	for i, segment := range segments {
+		tree, ok := r.trees[sha]
+		if !ok {
+			var resp *github.Response
+			var err error
+			tree, resp, err = r.client.Git.GetTree(ctx, r.owner, r.repo, sha, false)
+			if err != nil {
+				return nil, resp, err
+			}
+			_ = resp.Body.Close()
+			r.trees[sha] = tree
+		}
+
+		var found *github.TreeEntry
+		for _, entry := range tree.Entries {
+			if entry.GetPath() == segment {
+				found = entry
+				break
+			}
+		}
+		if found == nil || (i < len(segments)-1 && found.GetType() != "tree") {
+			return nil, nil, nil
+		}
+		if i == len(segments)-1 {
+			return found, nil, nil
+		}
+		sha = found.GetSHA()
+	}
+	return nil, nil, nil
+}
+
+// GetRepositoryTree creates a tool to list the files and directories of a repository recursively.
+func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_tree",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TREE_DESCRIPTION", "Get the tree of files and directories of a GitHub repository recursively, with their blob SHAs and sizes. Use the filters to explore large repositories, instead of listing directories one by one with get_file_contents")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`. Defaults to the default branch"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Directory to list the tree of, the root of the repository if omitted"),
+			),
+			mcp.WithString("pattern",
+				mcp.Description("Glob pattern the paths must match, e.g. 'src/**/*.go' or '*_test.{go,ts}'. '*' matches within a directory and '**' across directories. Patterns without a slash match file and directory names"),
+			),
+			mcp.WithArray("extensions",
+				mcp.Description("File extensions to include, e.g. ['.go', '.md']. Only files are returned when set"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithString("type",
+				mcp.Description("Only return files (blob) or directories (tree)"),
+				mcp.Enum("blob", "tree"),
+			),
+			mcp.WithNumber("max_depth",
+				mcp.Description("Maximum depth below path, 1 lists only its direct entries. Unlimited if omitted"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("min_size",
+				mcp.Description("Minimum file size in bytes. Only files are returned when set"),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("max_size",
+				mcp.Description("Maximum file size in bytes. Only files are returned when set"),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("max_entries",
+				mcp.Description(fmt.Sprintf("Maximum number of entries to return (default %d, max %d)", DefaultTreeMaxEntries, MaxTreeEntries)),
+				mcp.Min(1),
+				mcp.Max(MaxTreeEntries),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dir, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dir = strings.Trim(dir, "/")
+			pattern, err := OptionalParam[string](request, "pattern")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			extensions, err := OptionalStringArrayParam(request, "extensions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			entryType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxDepth, err := OptionalIntParam(request, "max_depth")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			minSize, err := OptionalIntParam(request, "min_size")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxSize, err := OptionalIntParam(request, "max_size")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxEntries, err := OptionalIntParamWithDefault(request, "max_entries", DefaultTreeMaxEntries)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxEntries < 1 || maxEntries > MaxTreeEntries {
+				return mcp.NewToolResultError(fmt.Sprintf("max_entries must be between 1 and %d", MaxTreeEntries)), nil
+			}
+
+			filter := treeFilter{entryType: entryType, maxDepth: maxDepth, minSize: minSize, maxSize: maxSize}
+			if pattern != "" {
+				if filter.pattern, err = compileGlob(pattern); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				filter.basename = !strings.Contains(pattern, "/")
+			}
+			for _, ext := range extensions {
+				ext = strings.ToLower(ext)
+				if !strings.HasPrefix(ext, ".") {
+					ext = "." + ext
+				}
+				filter.extensions = append(filter.extensions, ext)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			treeSHA := rawOpts.SHA
+			prefix := ""
+			if dir != "" {
+				entry, resp, err := newTreeReader(client, owner, repo).entry(ctx, rawOpts.SHA, dir)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get tree", resp, err), nil
+				}
+				if entry == nil || entry.GetType() != "tree" {
+					return mcp.NewToolResultError(fmt.Sprintf("directory %q not found", dir)), nil
+				}
+				treeSHA = entry.GetSHA()
+				prefix = dir + "/"
+			}
+
+			result := RepositoryTree{SHA: rawOpts.SHA, Ref: rawOpts.Ref, Path: dir, Entries: []TreeEntry{}}
+			walker := &treeWalker{client: client, owner: owner, repo: repo, maxDepth: maxDepth, maxTrees: MaxTreeFetches}
+			resp, err := walker.walk(ctx, treeSHA, prefix, 1, func(entry TreeEntry, depth int) bool {
+				if !filter.matches(entry, depth) {
+					return true
+				}
+				result.TotalMatches++
+				if len(result.Entries) >= maxEntries {
+					return false
+				}
+				result.Entries = append(result.Entries, entry)
+				return true
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get tree", resp, err), nil
+			}
+			result.Truncated = walker.stopped || result.TotalMatches > len(result.Entries)
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func blobEntry(path string, size int) *github.TreeEntry {
+	return &github.TreeEntry{Path: github.Ptr(path), Type: github.Ptr("blob"), SHA: github.Ptr(path + "-sha"), Size: github.Ptr(size)}
+}
+
+func treeEntry(path, sha string) *github.TreeEntry {
+	return &github.TreeEntry{Path: github.Ptr(path), Type: github.Ptr("tree"), SHA: github.Ptr(sha)}
+}
+
+// mockTreesHandler serves a repository whose recursive root tree is truncated by the API.
+func mockTreesHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sha := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		recursive := r.URL.Query().Get("recursive") != ""
+		var tree *github.Tree
+		switch {
+		case sha == "commit-sha" && recursive:
+			tree = &github.Tree{SHA: github.Ptr(sha), Truncated: github.Ptr(true), Entries: []*github.TreeEntry{blobEntry("README.md", 100)}}
+		case sha == "commit-sha":
+			tree = &github.Tree{SHA: github.Ptr(sha), Entries: []*github.TreeEntry{
+				blobEntry("README.md", 100),
+				treeEntry("src", "src-sha"),
+				treeEntry("docs", "docs-sha"),
+			}}
+		case sha == "src-sha" && recursive:
+			tree = &github.Tree{SHA: github.Ptr(sha), Entries: []*github.TreeEntry{
+				blobEntry("main.go", 2000),
+				treeEntry("internal", "internal-sha"),
+				blobEntry("internal/util.go", 50000),
+				blobEntry("internal/util_test.go", 300),
+			}}
+		case sha == "src-sha":
+			tree = &github.Tree{SHA: github.Ptr(sha), Entries: []*github.TreeEntry{
+				blobEntry("main.go", 2000),
+				treeEntry("internal", "internal-sha"),
+			}}
+		case sha == "docs-sha":
+			tree = &github.Tree{SHA: github.Ptr(sha), Entries: []*github.TreeEntry{blobEntry("index.md", 10)}}
+		default:
+			t.Errorf("unexpected tree request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(mock.MustMarshal(tree))
+	}
+}
+
+func Test_GetRepositoryTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]any
+		expectedPaths   []string
+		expectedTotal   int
+		expectTruncated bool
+		expectedErrMsg  string
+	}{
+		{
+			name:          "walks subtrees when the recursive listing is truncated",
+			requestArgs:   map[string]any{},
+			expectedPaths: []string{"README.md", "src", "src/main.go", "src/internal", "src/internal/util.go", "src/internal/util_test.go", "docs", "docs/index.md"},
+		},
+		{
+			name:          "glob across directories",
+			requestArgs:   map[string]any{"pattern": "src/**/*.go"},
+			expectedPaths: []string{"src/main.go", "src/internal/util.go", "src/internal/util_test.go"},
+		},
+		{
+			name:          "glob of names with alternatives",
+			requestArgs:   map[string]any{"pattern": "*_test.{go,ts}"},
+			expectedPaths: []string{"src/internal/util_test.go"},
+		},
+		{
+			name:          "extensions",
+			requestArgs:   map[string]any{"extensions": []any{"MD"}},
+			expectedPaths: []string{"README.md", "docs/index.md"},
+		},
+		{
+			name:          "file sizes",
+			requestArgs:   map[string]any{"min_size": float64(200), "max_size": float64(5000)},
+			expectedPaths: []string{"src/main.go", "src/internal/util_test.go"},
+		},
+		{
+			name:          "directories only",
+			requestArgs:   map[string]any{"type": "tree"},
+			expectedPaths: []string{"src", "src/internal", "docs"},
+		},
+		{
+			name:          "max depth",
+			requestArgs:   map[string]any{"max_depth": float64(1)},
+			expectedPaths: []string{"README.md", "src", "docs"},
+		},
+		{
+			name:          "subdirectory",
+			requestArgs:   map[string]any{"path": "src/", "max_depth": float64(2)},
+			expectedPaths: []string{"src/main.go", "src/internal", "src/internal/util.go", "src/internal/util_test.go"},
+		},
+		{
+			name:            "max entries stops the walk",
+			requestArgs:     map[string]any{"max_entries": float64(2)},
+			expectedPaths:   []string{"README.md", "src"},
+			expectedTotal:   6,
+			expectTruncated: true,
+		},
+		{
+			name:           "unknown directory",
+			requestArgs:    map[string]any{"path": "lib"},
+			expectedErrMsg: `directory "lib" not found`,
+		},
+		{
+			name:           "invalid pattern",
+			requestArgs:    map[string]any{"pattern": "*.{go"},
+			expectedErrMsg: "unclosed '{'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, mockTreesHandler(t)),
+			))
+			_, handler := GetRepositoryTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "sha": "commit-sha"}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var tree RepositoryTree
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &tree))
+			assert.Equal(t, "commit-sha", tree.SHA)
+			paths := make([]string, 0, len(tree.Entries))
+			for _, entry := range tree.Entries {
+				paths = append(paths, entry.Path)
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+			expectedTotal := tc.expectedTotal
+			if expectedTotal == 0 {
+				expectedTotal = len(tc.expectedPaths)
+			}
+			assert.Equal(t, expectedTotal, tree.TotalMatches)
+			assert.Equal(t, tc.expectTruncated, tree.Truncated)
+		})
+	}
+
+	t.Run("stops at the maximum number of trees", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, mockTreesHandler(t)),
+		))
+		walker := &treeWalker{client: client, owner: "owner", repo: "repo", maxTrees: 3}
+		var paths []string
+		_, err := walker.walk(context.Background(), "commit-sha", "", 1, func(entry TreeEntry, _ int) bool {
+			paths = append(paths, entry.Path)
+			return true
+		})
+		require.NoError(t, err)
+		// The root is fetched twice, recursively and by level, leaving a single subtree to fetch
+		assert.Equal(t, []string{"README.md", "src", "src/main.go", "src/internal", "src/internal/util.go", "src/internal/util_test.go", "docs"}, paths)
+		assert.True(t, walker.stopped)
+		assert.Equal(t, 3, walker.treesCount)
+	})
+
+	t.Run("reports blob SHAs and sizes", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, mockTreesHandler(t)),
+		))
+		_, handler := GetRepositoryTree(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner", "repo": "repo", "sha": "commit-sha", "pattern": "README.md",
+		}))
+		require.NoError(t, err)
+		var tree RepositoryTree
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &tree))
+		assert.Equal(t, []TreeEntry{{Path: "README.md", Type: "blob", SHA: "README.md-sha", Size: 100}}, tree.Entries)
+	})
+}
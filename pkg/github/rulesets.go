@@ -0,0 +1,553 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// BranchRule is a rule that applies to a branch, with the ruleset it comes from.
+type BranchRule struct {
+	Type              string          `json:"type"`
+	RulesetID         int64           `json:"ruleset_id"`
+	RulesetSource     string          `json:"ruleset_source"`
+	RulesetSourceType string          `json:"ruleset_source_type"`
+	Parameters        json.RawMessage `json:"parameters,omitempty"`
+}
+
+// BranchRules is the result of get_branch_rules.
+type BranchRules struct {
+	Branch string       `json:"branch"`
+	Rules  []BranchRule `json:"rules"`
+}
+
+// GetBranchProtection creates a tool to get the classic branch protection of a branch.
+func GetBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_protection",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_PROTECTION_DESCRIPTION", "Get the classic branch protection of a branch in a GitHub repository, such as required reviews and status checks. Rulesets are not included, use get_branch_rules to get all rules that apply to a branch")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_PROTECTION_USER_TITLE", "Get branch protection"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// The API only evaluates rulesets for branches, a tag name would be looked up as a branch
+			if strings.HasPrefix(branch, "refs/tags/") {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a tag, only the rules of branches can be listed; the rules of tags are in the rulesets with target tag listed by list_rulesets", branch)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if errors.Is(err, github.ErrBranchNotProtected) {
+				return mcp.NewToolResultText(fmt.Sprintf("branch %s has no classic branch protection. Rulesets may still apply, see get_branch_rules", branch)), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get branch protection: %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(protection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetBranchRules creates a tool to get the rules of all rulesets that apply to a branch.
+func GetBranchRules(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_rules",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_RULES_DESCRIPTION", "Get the rules that apply to a branch in a GitHub repository, from the active rulesets of the repository, its organization and enterprise. Use this to find out why a push or merge was rejected. Only branches are covered, the rules of tags are in the rulesets with target tag listed by list_rulesets")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_RULES_USER_TITLE", "Get rules for a branch"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name. The branch does not need to exist, to check the rules a new branch would get. Tags are not supported"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// The API only evaluates rulesets for branches, a tag name would be looked up as a branch
+			if strings.HasPrefix(branch, "refs/tags/") {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a tag, only the rules of branches can be listed; the rules of tags are in the rulesets with target tag listed by list_rulesets", branch)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// github.BranchRules groups the rules by type and cannot be marshaled back, so the
+			// rules are decoded as returned by the API
+			result := BranchRules{Branch: branch, Rules: []BranchRule{}}
+			for page := 1; page != 0; {
+				u := fmt.Sprintf("repos/%s/%s/rules/branches/%s?per_page=100&page=%d", owner, repo, branch, page)
+				req, err := client.NewRequest(http.MethodGet, u, nil)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create request: %w", err)
+				}
+				var rules []BranchRule
+				resp, err := client.Do(ctx, req, &rules)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get rules for branch: %s", branch),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				result.Rules = append(result.Rules, rules...)
+				page = resp.NextPage
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListRulesets creates a tool to list the rulesets of a repository.
+func ListRulesets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_rulesets",
+			mcp.WithDescription(t("TOOL_LIST_RULESETS_DESCRIPTION", "List the rulesets of a GitHub repository, including those of its organization and enterprise")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RULESETS_USER_TITLE", "List rulesets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("include_parents",
+				mcp.Description("Include the rulesets of the organization and enterprise that apply to the repository. Default is true"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeParents := true
+			if v, ok, err := OptionalParamOK[bool](request, "include_parents"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				includeParents = v
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rulesets, resp, err := client.Repositories.GetAllRulesets(ctx, owner, repo, &github.RepositoryListRulesetsOptions{
+				IncludesParents: github.Ptr(includeParents),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list rulesets",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(rulesets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRuleset creates a tool to get a ruleset of a repository with its conditions and rules.
+func GetRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ruleset",
+			mcp.WithDescription(t("TOOL_GET_RULESET_DESCRIPTION", "Get a ruleset of a GitHub repository with its conditions, rules and bypass actors")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RULESET_USER_TITLE", "Get ruleset"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("ID of the ruleset"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Parents are included, so that rulesets listed by list_rulesets can all be fetched
+			ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get ruleset: %d", rulesetID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// rulesetOptions are the options of create_ruleset and update_ruleset.
+func rulesetOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("target",
+			mcp.Description("What the ruleset applies to. Default is branch"),
+			mcp.Enum("branch", "tag", "push"),
+		),
+		mcp.WithObject("conditions",
+			mcp.Description(`Refs the ruleset applies to, e.g. {"ref_name": {"include": ["~DEFAULT_BRANCH", "refs/heads/release/*"], "exclude": []}}`),
+		),
+		mcp.WithArray("rules",
+			mcp.Description(`Rules of the ruleset, each with a type and parameters, e.g. [{"type": "deletion"}, {"type": "pull_request", "parameters": {"required_approving_review_count": 1, "dismiss_stale_reviews_on_push": true, "require_code_owner_review": false, "require_last_push_approval": false, "required_review_thread_resolution": false}}]`),
+			mcp.Items(map[string]any{
+				"type":     "object",
+				"required": []string{"type"},
+				"properties": map[string]any{
+					"type": map[string]any{
+						"type":        "string",
+						"description": "Rule type, e.g. creation, update, deletion, required_linear_history, required_signatures, pull_request, required_status_checks, non_fast_forward",
+					},
+					"parameters": map[string]any{
+						"type":        "object",
+						"description": "Parameters of the rule type",
+					},
+				},
+			}),
+		),
+		mcp.WithArray("bypass_actors",
+			mcp.Description(`Actors that can bypass the ruleset, e.g. [{"actor_id": 5, "actor_type": "RepositoryRole", "bypass_mode": "always"}]`),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"actor_id": map[string]any{
+						"type": "number",
+					},
+					"actor_type": map[string]any{
+						"type": "string",
+						"enum": []string{"Integration", "OrganizationAdmin", "RepositoryRole", "Team", "DeployKey"},
+					},
+					"bypass_mode": map[string]any{
+						"type": "string",
+						"enum": []string{"always", "pull_request"},
+					},
+				},
+			}),
+		),
+	}
+}
+
+// rulesetBody returns the fields of a ruleset provided in the request.
+func rulesetBody(request mcp.CallToolRequest) (map[string]any, error) {
+	body := map[string]any{}
+	args := request.GetArguments()
+	for _, field := range []string{"name", "target", "enforcement"} {
+		v, ok, err := OptionalParamOK[string](request, field)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			body[field] = v
+		}
+	}
+	if v, ok := args["conditions"]; ok {
+		conditions, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("conditions must be an object")
+		}
+		body["conditions"] = conditions
+	}
+	for _, field := range []string{"rules", "bypass_actors"} {
+		v, ok := args[field]
+		if !ok {
+			continue
+		}
+		items, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s must be an array of objects", field)
+		}
+		for i, item := range items {
+			if _, ok := item.(map[string]any); !ok {
+				return nil, fmt.Errorf("%s[%d] must be an object", field, i)
+			}
+		}
+		body[field] = items
+	}
+	return body, nil
+}
+
+// writeRuleset sends a ruleset with only the provided fields, since RepositoriesService.UpdateRuleset
+// always sends the name and enforcement of the ruleset.
+func writeRuleset(ctx context.Context, client *github.Client, method, u string, body map[string]any) (*github.RepositoryRuleset, *github.Response, error) {
+	req, err := client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	ruleset := new(github.RepositoryRuleset)
+	resp, err := client.Do(ctx, req, ruleset)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ruleset, resp, nil
+}
+
+// CreateRuleset creates a tool to create a ruleset in a repository.
+func CreateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_CREATE_RULESET_DESCRIPTION", "Create a ruleset in a GitHub repository to protect branches or tags. Use enforcement evaluate to try a ruleset without blocking anyone")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_CREATE_RULESET_USER_TITLE", "Create ruleset"),
+			ReadOnlyHint: ToBoolPtr(false),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the ruleset"),
+		),
+		mcp.WithString("enforcement",
+			mcp.Required(),
+			mcp.Description("disabled, active, or evaluate to only report violations"),
+			mcp.Enum("disabled", "active", "evaluate"),
+		),
+	}
+	return mcp.NewTool("create_ruleset", append(options, rulesetOptions()...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := RequiredParam[string](request, "enforcement"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := rulesetBody(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ruleset, resp, err := writeRuleset(ctx, client, http.MethodPost, fmt.Sprintf("repos/%s/%s/rulesets", owner, repo), body)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create ruleset: %s", name),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateRuleset creates a tool to update a ruleset of a repository.
+func UpdateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_UPDATE_RULESET_DESCRIPTION", "Update a ruleset of a GitHub repository. Only the provided fields are changed, and rules, conditions and bypass_actors replace the current ones")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_UPDATE_RULESET_USER_TITLE", "Update ruleset"),
+			ReadOnlyHint: ToBoolPtr(false),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithNumber("ruleset_id",
+			mcp.Required(),
+			mcp.Description("ID of the ruleset"),
+		),
+		mcp.WithString("name",
+			mcp.Description("New name of the ruleset"),
+		),
+		mcp.WithString("enforcement",
+			mcp.Description("disabled, active, or evaluate to only report violations"),
+			mcp.Enum("disabled", "active", "evaluate"),
+		),
+	}
+	return mcp.NewTool("update_ruleset", append(options, rulesetOptions()...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := rulesetBody(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(body) == 0 {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ruleset, resp, err := writeRuleset(ctx, client, http.MethodPut, fmt.Sprintf("repos/%s/%s/rulesets/%d", owner, repo, rulesetID), body)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update ruleset: %d", rulesetID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,390 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetBranchProtection(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					&github.Protection{
+						RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2},
+					},
+				),
+			),
+			expectedText: `"required_approving_review_count":2`,
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+			),
+			expectedText: "branch main has no classic branch protection",
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not found"}`),
+				),
+			),
+			expectedErrMsg: "failed to get branch protection: main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "branch": "main"}))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
+
+func Test_GetBranchRules(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchRules(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_rules", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/owner/repo/rules/branches/main", r.URL.Path)
+				if r.URL.Query().Get("page") == "1" {
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/rules/branches/main?per_page=100&page=2>; rel="next"`)
+					_, _ = w.Write([]byte(`[
+						{"type": "deletion", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 1},
+						{"type": "pull_request", "ruleset_source_type": "Organization", "ruleset_source": "owner", "ruleset_id": 2, "parameters": {"required_approving_review_count": 2}}
+					]`))
+					return
+				}
+				_, _ = w.Write([]byte(`[{"type": "required_signatures", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 1}]`))
+			}),
+		),
+	))
+	_, handler := GetBranchRules(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "branch": "main"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var rules BranchRules
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &rules))
+	assert.Equal(t, "main", rules.Branch)
+	require.Len(t, rules.Rules, 3)
+	assert.Equal(t, "deletion", rules.Rules[0].Type)
+	assert.Equal(t, "pull_request", rules.Rules[1].Type)
+	assert.Equal(t, "Organization", rules.Rules[1].RulesetSourceType)
+	assert.JSONEq(t, `{"required_approving_review_count": 2}`, string(rules.Rules[1].Parameters))
+	assert.Equal(t, "required_signatures", rules.Rules[2].Type)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "branch": "refs/tags/v1.0.0"}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "refs/tags/v1.0.0 is a tag")
+}
+
+func Test_ListRulesets(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRulesets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_rulesets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRulesets := []*github.RepositoryRuleset{
+		{ID: github.Ptr(int64(1)), Name: "main protection", Enforcement: github.RulesetEnforcementActive},
+		{ID: github.Ptr(int64(2)), Name: "org policy", Enforcement: github.RulesetEnforcementEvaluate},
+	}
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]any
+		expectedQuery map[string]string
+	}{
+		{
+			name:          "includes parents by default",
+			requestArgs:   map[string]any{},
+			expectedQuery: map[string]string{"includes_parents": "true", "page": "1", "per_page": "30"},
+		},
+		{
+			name:          "repository rulesets only",
+			requestArgs:   map[string]any{"include_parents": false},
+			expectedQuery: map[string]string{"includes_parents": "false", "page": "1", "per_page": "30"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					expectQueryParams(t, tc.expectedQuery).andThen(
+						mockResponse(t, http.StatusOK, mockRulesets),
+					),
+				),
+			))
+			_, handler := ListRulesets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var rulesets []*github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &rulesets))
+			require.Len(t, rulesets, 2)
+			assert.Equal(t, "org policy", rulesets[1].Name)
+		})
+	}
+}
+
+func Test_GetRuleset(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+			expectPath(t, "/repos/owner/repo/rulesets/1").andThen(
+				mockResponse(t, http.StatusOK, `{"id": 1, "name": "main protection", "enforcement": "active", "rules": [{"type": "deletion"}, {"type": "non_fast_forward"}]}`),
+			),
+		),
+	))
+	_, handler := GetRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "ruleset_id": float64(1)}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var ruleset github.RepositoryRuleset
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ruleset))
+	assert.Equal(t, "main protection", ruleset.Name)
+	require.NotNil(t, ruleset.Rules)
+	assert.NotNil(t, ruleset.Rules.Deletion)
+	assert.NotNil(t, ruleset.Rules.NonFastForward)
+}
+
+func Test_CreateRuleset(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "enforcement"})
+
+	conditions := map[string]any{"ref_name": map[string]any{"include": []any{"~DEFAULT_BRANCH"}, "exclude": []any{}}}
+	rules := []any{
+		map[string]any{"type": "deletion"},
+		map[string]any{"type": "pull_request", "parameters": map[string]any{"required_approving_review_count": float64(1)}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name: "create ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":        "main protection",
+						"target":      "branch",
+						"enforcement": "evaluate",
+						"conditions":  conditions,
+						"rules":       rules,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, `{"id": 3, "name": "main protection", "enforcement": "evaluate"}`),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"name":        "main protection",
+				"target":      "branch",
+				"enforcement": "evaluate",
+				"conditions":  conditions,
+				"rules":       rules,
+			},
+		},
+		{
+			name:         "missing enforcement",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"name": "main protection",
+			},
+			expectedErrMsg: "missing required parameter: enforcement",
+		},
+		{
+			name:         "rules are not objects",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"name":        "main protection",
+				"enforcement": "active",
+				"rules":       []any{"deletion"},
+			},
+			expectedErrMsg: "rules[0] must be an object",
+		},
+		{
+			name: "invalid rule",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Invalid rule 'unknown'"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"name":        "main protection",
+				"enforcement": "active",
+				"rules":       []any{map[string]any{"type": "unknown"}},
+			},
+			expectedErrMsg: "failed to create ruleset: main protection",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var ruleset github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ruleset))
+			assert.Equal(t, int64(3), ruleset.GetID())
+		})
+	}
+}
+
+func Test_UpdateRuleset(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name: "only provided fields are sent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					expectPath(t, "/repos/owner/repo/rulesets/3").andThen(
+						expectRequestBody(t, map[string]any{"enforcement": "active", "bypass_actors": []any{}}).andThen(
+							mockResponse(t, http.StatusOK, `{"id": 3, "name": "main protection", "enforcement": "active"}`),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{"enforcement": "active", "bypass_actors": []any{}},
+		},
+		{
+			name:           "no update parameters",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{},
+			expectedErrMsg: "No update parameters provided.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "ruleset_id": float64(3)}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var ruleset github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ruleset))
+			assert.Equal(t, github.RulesetEnforcementActive, ruleset.Enforcement)
+		})
+	}
+}
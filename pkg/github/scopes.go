@@ -0,0 +1,212 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// TokenTypeClassic is a token that reports its OAuth scopes, i.e. a classic PAT or an OAuth app token.
+	TokenTypeClassic = "classic"
+	// TokenTypeFineGrained is a token without OAuth scopes, i.e. a fine-grained PAT or a GitHub App token.
+	TokenTypeFineGrained = "fine_grained"
+)
+
+// scopeRequirement lists the OAuth scopes of which a token needs at least one to use the read or
+// write tools of a toolset. An empty list means no scope is required.
+type scopeRequirement struct {
+	read  []string
+	write []string
+}
+
+var (
+	repoWriteScopes     = []string{"repo", "public_repo"}
+	securityEventScopes = []string{"security_events", "repo", "public_repo"}
+	notificationScopes  = []string{"notifications", "repo"}
+)
+
+// toolsetScopes are the scopes required by the tools of each toolset. Read tools of repository
+// toolsets require no scope, as they work on public repositories without one.
+var toolsetScopes = map[string]scopeRequirement{
+	"repos":             {write: repoWriteScopes},
+	"issues":            {write: repoWriteScopes},
+	"pull_requests":     {write: repoWriteScopes},
+	"actions":           {write: []string{"repo"}},
+	"code_security":     {read: securityEventScopes, write: securityEventScopes},
+	"secret_protection": {read: securityEventScopes, write: securityEventScopes},
+	"dependabot":        {read: securityEventScopes, write: securityEventScopes},
+	"notifications":     {read: notificationScopes, write: notificationScopes},
+	"gists":             {write: []string{"gist"}},
+}
+
+// toolScopes override the toolset requirement for individual tools.
+var toolScopes = map[string][]string{
+	"get_teams":        {"read:org"},
+	"get_team_members": {"read:org"},
+}
+
+// impliedScopes are the scopes granted by a broader scope.
+var impliedScopes = map[string][]string{
+	"repo":             {"public_repo", "repo:status", "repo_deployment", "repo:invite", "security_events"},
+	"admin:org":        {"write:org", "read:org"},
+	"write:org":        {"read:org"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"write:discussion": {"read:discussion"},
+}
+
+// RequiredScopes returns the OAuth scopes of which a token needs at least one to use a tool.
+func RequiredScopes(toolset string, tool server.ServerTool) []string {
+	if scopes, ok := toolScopes[tool.Tool.Name]; ok {
+		return scopes
+	}
+	requirement := toolsetScopes[toolset]
+	if tool.Tool.Annotations.ReadOnlyHint != nil && *tool.Tool.Annotations.ReadOnlyHint {
+		return requirement.read
+	}
+	return requirement.write
+}
+
+// AnnotateRequiredScopes appends the OAuth scopes each tool requires to its description, so that
+// the scopes a classic token needs are visible without hiding any tool.
+func AnnotateRequiredScopes(tsg *toolsets.ToolsetGroup) {
+	for name, toolset := range tsg.Toolsets {
+		toolset.MapTools(func(tool server.ServerTool) server.ServerTool {
+			return withRequiredScopes(tool, RequiredScopes(name, tool))
+		})
+	}
+}
+
+func withRequiredScopes(tool server.ServerTool, scopes []string) server.ServerTool {
+	if len(scopes) == 0 {
+		return tool
+	}
+	description := strings.TrimSpace(tool.Tool.Description)
+	if description != "" && !strings.HasSuffix(description, ".") {
+		description += "."
+	}
+	tool.Tool.Description = strings.TrimSpace(fmt.Sprintf("%s Requires one of the OAuth scopes: %s.", description, strings.Join(scopes, ", ")))
+	return tool
+}
+
+// HiddenTool is a tool that was not offered because the token cannot use it.
+type HiddenTool struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// TokenAccess describes what the token of the server can do, as determined at startup.
+type TokenAccess struct {
+	TokenType   string       `json:"token_type"`
+	Scopes      []string     `json:"scopes,omitempty"`
+	HiddenTools []HiddenTool `json:"hidden_tools,omitempty"`
+	// Note explains the limits of the check, e.g. that fine-grained tokens are not filtered.
+	Note string `json:"note,omitempty"`
+
+	grantedScopes map[string]bool
+	// unavailableToolsets maps toolsets the token cannot use at all to the reason.
+	unavailableToolsets map[string]string
+}
+
+// NewClassicTokenAccess creates the TokenAccess of a token with the given OAuth scopes.
+func NewClassicTokenAccess(scopes []string) *TokenAccess {
+	access := &TokenAccess{
+		TokenType:     TokenTypeClassic,
+		Scopes:        scopes,
+		grantedScopes: make(map[string]bool),
+	}
+	for _, scope := range scopes {
+		access.grantedScopes[scope] = true
+		for _, implied := range impliedScopes[scope] {
+			access.grantedScopes[implied] = true
+		}
+	}
+	return access
+}
+
+// ParseOAuthScopes parses the value of the X-OAuth-Scopes header.
+func ParseOAuthScopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// CheckTokenAccess determines what the token of the client can do. Classic tokens report their
+// scopes in the X-OAuth-Scopes header. Fine-grained permissions cannot be listed, so other tokens
+// are not filtered: only notifications, which they never support, are probed and all other tools
+// are kept even when the token lacks the permission they need.
+func CheckTokenAccess(ctx context.Context, client *github.Client) (*TokenAccess, error) {
+	_, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	if values := resp.Header.Values("X-OAuth-Scopes"); len(values) > 0 {
+		return NewClassicTokenAccess(ParseOAuthScopes(strings.Join(values, ","))), nil
+	}
+
+	access := &TokenAccess{
+		TokenType:           TokenTypeFineGrained,
+		Note:                "fine-grained and GitHub App tokens cannot report their permissions, so tools are not filtered by them; only notification tools are hidden if the token cannot access notifications, and other tools fail with a 403 error when a permission is missing",
+		unavailableToolsets: make(map[string]string),
+	}
+	_, resp, err = client.Activity.ListNotifications(ctx, &github.NotificationListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if resp != nil {
+		_ = resp.Body.Close()
+		if err != nil && resp.StatusCode == http.StatusForbidden {
+			access.unavailableToolsets["notifications"] = "the token cannot access notifications, fine-grained and GitHub App tokens do not support them"
+		}
+	}
+	return access, nil
+}
+
+// allows reports whether the token can use a tool of the given toolset, and the reason if not.
+func (a *TokenAccess) allows(toolset string, tool server.ServerTool) (bool, string) {
+	if reason, unavailable := a.unavailableToolsets[toolset]; unavailable {
+		return false, reason
+	}
+	if a.TokenType != TokenTypeClassic {
+		return true, ""
+	}
+	required := RequiredScopes(toolset, tool)
+	if len(required) == 0 {
+		return true, ""
+	}
+	for _, scope := range required {
+		if a.grantedScopes[scope] {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("requires one of the scopes: %s", strings.Join(required, ", "))
+}
+
+// HideUnusableTools removes the tools the token cannot use from the toolset group and records
+// them, with the reason, in the TokenAccess. A nil TokenAccess leaves all tools in place.
+func HideUnusableTools(tsg *toolsets.ToolsetGroup, access *TokenAccess) {
+	if access == nil {
+		return
+	}
+	for name, toolset := range tsg.Toolsets {
+		toolset.RemoveTools(func(tool server.ServerTool) bool {
+			ok, reason := access.allows(name, tool)
+			if !ok {
+				access.HiddenTools = append(access.HiddenTools, HiddenTool{Name: tool.Tool.Name, Reason: reason})
+			}
+			return !ok
+		})
+	}
+	sort.Slice(access.HiddenTools, func(i, j int) bool {
+		return access.HiddenTools[i].Name < access.HiddenTools[j].Name
+	})
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubTool(name string, readOnly bool) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(readOnly)})),
+	}
+}
+
+func Test_ParseOAuthScopes(t *testing.T) {
+	assert.Equal(t, []string{"repo", "read:org", "gist"}, ParseOAuthScopes("repo, read:org,gist"))
+	assert.Nil(t, ParseOAuthScopes(""))
+}
+
+func Test_RequiredScopes(t *testing.T) {
+	assert.Empty(t, RequiredScopes("issues", stubTool("get_issue", true)))
+	assert.Equal(t, []string{"repo", "public_repo"}, RequiredScopes("issues", stubTool("create_issue", false)))
+	assert.Equal(t, []string{"read:org"}, RequiredScopes("context", stubTool("get_teams", true)))
+	assert.Empty(t, RequiredScopes("unknown", stubTool("some_tool", false)))
+}
+
+func Test_HideUnusableTools(t *testing.T) {
+	newGroup := func() *toolsets.ToolsetGroup {
+		tsg := toolsets.NewToolsetGroup(false)
+		issues := toolsets.NewToolset("issues", "Issues").
+			AddReadTools(stubTool("get_issue", true)).
+			AddWriteTools(stubTool("create_issue", false))
+		issues.Enabled = true
+		notifications := toolsets.NewToolset("notifications", "Notifications").
+			AddReadTools(stubTool("list_notifications", true))
+		notifications.Enabled = true
+		tsg.AddToolset(issues)
+		tsg.AddToolset(notifications)
+		return tsg
+	}
+	activeToolNames := func(tsg *toolsets.ToolsetGroup) []string {
+		var names []string
+		for _, toolset := range tsg.Toolsets {
+			for _, tool := range toolset.GetActiveTools() {
+				names = append(names, tool.Tool.Name)
+			}
+		}
+		return names
+	}
+
+	t.Run("classic token without scopes", func(t *testing.T) {
+		tsg := newGroup()
+		access := NewClassicTokenAccess(nil)
+		HideUnusableTools(tsg, access)
+
+		assert.ElementsMatch(t, []string{"get_issue"}, activeToolNames(tsg))
+		assert.Equal(t, []HiddenTool{
+			{Name: "create_issue", Reason: "requires one of the scopes: repo, public_repo"},
+			{Name: "list_notifications", Reason: "requires one of the scopes: notifications, repo"},
+		}, access.HiddenTools)
+	})
+
+	t.Run("implied scopes are granted", func(t *testing.T) {
+		tsg := newGroup()
+		access := NewClassicTokenAccess([]string{"repo"})
+		HideUnusableTools(tsg, access)
+
+		assert.ElementsMatch(t, []string{"get_issue", "create_issue", "list_notifications"}, activeToolNames(tsg))
+		assert.Empty(t, access.HiddenTools)
+	})
+
+	t.Run("nil access keeps all tools", func(t *testing.T) {
+		tsg := newGroup()
+		HideUnusableTools(tsg, nil)
+		assert.Len(t, activeToolNames(tsg), 3)
+	})
+}
+
+func Test_AnnotateRequiredScopes(t *testing.T) {
+	createIssue := stubTool("create_issue", false)
+	createIssue.Tool.Description = "Create a new issue"
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issues").
+		AddReadTools(stubTool("get_issue", true)).
+		AddWriteTools(createIssue))
+
+	AnnotateRequiredScopes(tsg)
+
+	descriptions := map[string]string{}
+	for _, tool := range tsg.Toolsets["issues"].GetAvailableTools() {
+		descriptions[tool.Tool.Name] = tool.Tool.Description
+	}
+	assert.Equal(t, "Create a new issue. Requires one of the OAuth scopes: repo, public_repo.", descriptions["create_issue"])
+	assert.Empty(t, descriptions["get_issue"])
+}
+
+func Test_CheckTokenAccess(t *testing.T) {
+	userHandler := func(scopes *string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			if scopes != nil {
+				w.Header().Set("X-OAuth-Scopes", *scopes)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"login":"octocat"}`))
+		}
+	}
+
+	t.Run("classic token", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetUser, userHandler(github.Ptr("repo, read:org"))),
+		))
+		access, err := CheckTokenAccess(context.Background(), client)
+		require.NoError(t, err)
+		assert.Equal(t, TokenTypeClassic, access.TokenType)
+		assert.Equal(t, []string{"repo", "read:org"}, access.Scopes)
+		assert.Empty(t, access.Note)
+	})
+
+	t.Run("fine-grained token without notifications access", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetUser, userHandler(nil)),
+			mock.WithRequestMatchHandler(mock.GetNotifications, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message":"Resource not accessible by personal access token"}`))
+			})),
+		))
+		access, err := CheckTokenAccess(context.Background(), client)
+		require.NoError(t, err)
+		assert.Equal(t, TokenTypeFineGrained, access.TokenType)
+		assert.Contains(t, access.Note, "not filtered")
+
+		ok, reason := access.allows("notifications", stubTool("list_notifications", true))
+		assert.False(t, ok)
+		assert.Contains(t, reason, "notifications")
+		ok, _ = access.allows("issues", stubTool("create_issue", false))
+		assert.True(t, ok)
+	})
+}
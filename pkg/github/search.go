@@ -71,7 +71,7 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to search repositories: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to search repositories", resp, body), nil
 			}
 
 			// Return either minimal or full response based on parameter
@@ -197,7 +197,7 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to search code: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to search code", resp, body), nil
 			}
 
 			r, err := json.Marshal(result)
@@ -261,7 +261,7 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 			if err != nil {
 				return nil, fmt.Errorf("failed to read response body: %w", err)
 			}
-			return mcp.NewToolResultError(fmt.Sprintf("failed to search %ss: %s", accountType, string(body))), nil
+			return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, fmt.Sprintf("failed to search %ss", accountType), resp, body), nil
 		}
 
 		minimalUsers := make([]MinimalUser, 0, len(result.Users))
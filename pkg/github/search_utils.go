@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"regexp"
 
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -103,7 +104,7 @@ func searchHandler(
 		if err != nil {
 			return nil, fmt.Errorf("%s: failed to read response body: %w", errorPrefix, err)
 		}
-		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", errorPrefix, string(body))), nil
+		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, errorPrefix, resp, body), nil
 	}
 
 	r, err := json.Marshal(result)
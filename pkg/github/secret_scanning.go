@@ -69,7 +69,7 @@ func GetSecretScanningAlert(getClient GetClientFn, t translations.TranslationHel
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get alert", resp, body), nil
 			}
 
 			r, err := json.Marshal(alert)
@@ -150,7 +150,7 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list alerts", resp, body), nil
 			}
 
 			r, err := json.Marshal(alerts)
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -170,7 +171,7 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list advisories: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list advisories", resp, body), nil
 			}
 
 			r, err := json.Marshal(advisories)
@@ -259,7 +260,7 @@ func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.Tran
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list repository advisories: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list repository advisories", resp, body), nil
 			}
 
 			r, err := json.Marshal(advisories)
@@ -304,7 +305,7 @@ func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.Translation
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get advisory: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get advisory", resp, body), nil
 			}
 
 			r, err := json.Marshal(advisory)
@@ -384,7 +385,7 @@ func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.T
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list organization repository advisories: %s", string(body))), nil
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list organization repository advisories", resp, body), nil
 			}
 
 			r, err := json.Marshal(advisories)
@@ -242,12 +242,64 @@ func WithCursorPagination() mcp.ToolOption {
 	}
 }
 
+// WithFetchAll adds opt-in automatic pagination parameters to a list tool.
+// Tools using this option should honour the parameters via OptionalFetchAllParams.
+func WithFetchAll() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithBoolean("fetch_all",
+			mcp.Description(fmt.Sprintf("Fetch all pages of results in one call instead of a single page, stopping at max_items (default %d)", DefaultMaxFetchItems)),
+		)(tool)
+
+		mcp.WithNumber("max_items",
+			mcp.Description(fmt.Sprintf("Maximum number of items to return when fetching multiple pages (min 1, max %d). Implies fetch_all.", MaxFetchItems)),
+			mcp.Min(1),
+			mcp.Max(MaxFetchItems),
+		)(tool)
+	}
+}
+
 type PaginationParams struct {
 	Page    int
 	PerPage int
 	After   string
 }
 
+// FetchAllParams holds the automatic pagination parameters added by WithFetchAll.
+type FetchAllParams struct {
+	FetchAll bool
+	MaxItems int
+}
+
+// OptionalFetchAllParams returns the "fetch_all" and "max_items" parameters from the request.
+// Providing "max_items" on its own also enables fetching all pages. "max_items" defaults to
+// DefaultMaxFetchItems and is clamped to MaxFetchItems.
+func OptionalFetchAllParams(r mcp.CallToolRequest) (FetchAllParams, error) {
+	fetchAll, err := OptionalParam[bool](r, "fetch_all")
+	if err != nil {
+		return FetchAllParams{}, err
+	}
+	maxItems, err := OptionalIntParam(r, "max_items")
+	if err != nil {
+		return FetchAllParams{}, err
+	}
+	if maxItems < 0 {
+		return FetchAllParams{}, fmt.Errorf("max_items value %d cannot be negative", maxItems)
+	}
+	if maxItems > 0 {
+		fetchAll = true
+	}
+	if maxItems == 0 {
+		maxItems = DefaultMaxFetchItems
+	}
+	if maxItems > MaxFetchItems {
+		maxItems = MaxFetchItems
+	}
+	return FetchAllParams{
+		FetchAll: fetchAll,
+		MaxItems: maxItems,
+	}, nil
+}
+
 // OptionalPaginationParams returns the "page", "perPage", and "after" parameters from the request,
 // or their default values if not present, "page" default is 1, "perPage" default is 30.
 // In future, we may want to make the default values configurable, or even have this
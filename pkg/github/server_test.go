@@ -560,3 +560,80 @@ func TestOptionalPaginationParams(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionalFetchAllParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]any
+		expected    FetchAllParams
+		expectError bool
+	}{
+		{
+			name:   "no parameters, fetch all disabled",
+			params: map[string]any{},
+			expected: FetchAllParams{
+				FetchAll: false,
+				MaxItems: DefaultMaxFetchItems,
+			},
+		},
+		{
+			name: "fetch_all with default max_items",
+			params: map[string]any{
+				"fetch_all": true,
+			},
+			expected: FetchAllParams{
+				FetchAll: true,
+				MaxItems: DefaultMaxFetchItems,
+			},
+		},
+		{
+			name: "max_items implies fetch_all",
+			params: map[string]any{
+				"max_items": float64(50),
+			},
+			expected: FetchAllParams{
+				FetchAll: true,
+				MaxItems: 50,
+			},
+		},
+		{
+			name: "max_items is clamped",
+			params: map[string]any{
+				"fetch_all": true,
+				"max_items": float64(MaxFetchItems + 1),
+			},
+			expected: FetchAllParams{
+				FetchAll: true,
+				MaxItems: MaxFetchItems,
+			},
+		},
+		{
+			name: "negative max_items",
+			params: map[string]any{
+				"max_items": float64(-1),
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid fetch_all parameter",
+			params: map[string]any{
+				"fetch_all": "yes",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.params)
+			result, err := OptionalFetchAllParams(request)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
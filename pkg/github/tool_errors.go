@@ -0,0 +1,28 @@
+package github
+
+import (
+	"context"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrorResultTool converts errors of GitHub requests returned by a tool handler into error results
+// carrying an ErrorInfo, so that clients see a classified tool error instead of a protocol error.
+// Errors that do not originate from GitHub, such as failures to build a client, are returned as is.
+func ErrorResultTool(st server.ServerTool) server.ServerTool {
+	handler := st.Handler
+	st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err == nil {
+			return result, nil
+		}
+		info, ok := ghErrors.ClassifyError(err)
+		if !ok {
+			return result, err
+		}
+		return ghErrors.WithErrorInfo(mcp.NewToolResultError(err.Error()), info), nil
+	}
+	return st
+}
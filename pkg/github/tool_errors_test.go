@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ErrorResultTool(t *testing.T) {
+	stub := func(err error) server.ServerTool {
+		return ErrorResultTool(server.ServerTool{
+			Tool: mcp.NewTool("stub_tool"),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return nil, err
+			},
+		})
+	}
+
+	t.Run("GitHub errors become classified error results", func(t *testing.T) {
+		apiErr := &github.ErrorResponse{
+			Response: &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{"X-Github-Request-Id": []string{"ABCD:1234"}}},
+			Message:  "Not Found",
+		}
+		result, err := stub(fmt.Errorf("failed to get issue: %w", apiErr)).Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get issue")
+
+		info, ok := result.Meta[ghErrors.ErrorMetaKey].(ghErrors.ErrorInfo)
+		require.True(t, ok)
+		assert.Equal(t, ghErrors.ErrorInfo{Category: ghErrors.CategoryNotFound, StatusCode: 404, RequestID: "ABCD:1234"}, info)
+	})
+
+	t.Run("other errors are returned unchanged", func(t *testing.T) {
+		original := errors.New("failed to get GitHub client")
+		result, err := stub(original).Handler(context.Background(), createMCPRequest(map[string]any{}))
+		assert.Nil(t, result)
+		assert.Equal(t, original, err)
+	})
+}
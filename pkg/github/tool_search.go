@@ -0,0 +1,229 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultToolSearchLimit is the number of results search_tools returns by default.
+	DefaultToolSearchLimit = 5
+	// MaxToolSearchLimit is the maximum number of results search_tools returns.
+	MaxToolSearchLimit = 25
+
+	// bm25K1 and bm25B are the usual BM25 parameters for term frequency saturation and length normalization.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// toolNameBoost is how many times the terms of a tool name are counted, as they describe the tool best.
+	toolNameBoost = 3
+)
+
+// toolDocument is an indexed tool.
+type toolDocument struct {
+	toolset     string
+	name        string
+	description string
+	terms       map[string]int
+	length      int
+}
+
+// ToolIndex is a BM25 index over the names, descriptions and parameter docs of the available
+// tools of all toolsets, used to find tools in dynamic mode without enabling toolsets first.
+type ToolIndex struct {
+	documents     []toolDocument
+	docFrequency  map[string]int
+	averageLength float64
+}
+
+// ToolSearchResult is a tool matching a search.
+type ToolSearchResult struct {
+	Name           string  `json:"name"`
+	Toolset        string  `json:"toolset"`
+	Description    string  `json:"description"`
+	Score          float64 `json:"score"`
+	ToolsetEnabled bool    `json:"toolset_enabled"`
+}
+
+// NewToolIndex indexes the available tools of all toolsets of the group.
+func NewToolIndex(toolsetGroup *toolsets.ToolsetGroup) *ToolIndex {
+	index := &ToolIndex{docFrequency: make(map[string]int)}
+
+	names := make([]string, 0, len(toolsetGroup.Toolsets))
+	for name := range toolsetGroup.Toolsets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	totalLength := 0
+	for _, toolsetName := range names {
+		toolset := toolsetGroup.Toolsets[toolsetName]
+		for _, st := range toolset.GetAvailableTools() {
+			doc := toolDocument{
+				toolset:     toolsetName,
+				name:        st.Tool.Name,
+				description: st.Tool.Description,
+				terms:       make(map[string]int),
+			}
+			add := func(text string, weight int) {
+				for _, term := range tokenize(text) {
+					doc.terms[term] += weight
+					doc.length += weight
+				}
+			}
+			add(st.Tool.Name, toolNameBoost)
+			add(st.Tool.Description, 1)
+			add(toolsetName+" "+toolset.Description, 1)
+			for param, schema := range st.Tool.InputSchema.Properties {
+				add(param, 1)
+				if property, ok := schema.(map[string]any); ok {
+					if description, ok := property["description"].(string); ok {
+						add(description, 1)
+					}
+				}
+			}
+
+			for term := range doc.terms {
+				index.docFrequency[term]++
+			}
+			totalLength += doc.length
+			index.documents = append(index.documents, doc)
+		}
+	}
+	if len(index.documents) > 0 {
+		index.averageLength = float64(totalLength) / float64(len(index.documents))
+	}
+	return index
+}
+
+// Search returns up to limit tools ranked by their BM25 score for the query.
+func (idx *ToolIndex) Search(query string, limit int) []ToolSearchResult {
+	queryTerms := tokenize(query)
+	n := float64(len(idx.documents))
+
+	results := []ToolSearchResult{}
+	for _, doc := range idx.documents {
+		score := 0.0
+		for _, term := range queryTerms {
+			frequency := float64(doc.terms[term])
+			if frequency == 0 {
+				continue
+			}
+			df := float64(idx.docFrequency[term])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			norm := 1 - bm25B + bm25B*float64(doc.length)/idx.averageLength
+			score += idf * frequency * (bm25K1 + 1) / (frequency + bm25K1*norm)
+		}
+		if score > 0 {
+			results = append(results, ToolSearchResult{
+				Name:        doc.name,
+				Toolset:     doc.toolset,
+				Description: doc.description,
+				Score:       math.Round(score*1000) / 1000,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// tokenize splits text into lowercase terms on anything but letters and digits, so that tool
+// names like list_issues match "list issues". A plural "s" is dropped so that "issue" matches "issues".
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len(field) > 3 && strings.HasSuffix(field, "s") && !strings.HasSuffix(field, "ss") && !strings.HasSuffix(field, "us") && !strings.HasSuffix(field, "is") {
+			field = strings.TrimSuffix(field, "s")
+		}
+		terms = append(terms, field)
+	}
+	return terms
+}
+
+// toolSearchResponse is the result of search_tools.
+type toolSearchResponse struct {
+	Results          []ToolSearchResult `json:"results"`
+	EnabledToolsets  []string           `json:"enabled_toolsets,omitempty"`
+	DisabledToolsets []string           `json:"disabled_toolsets,omitempty"`
+}
+
+func SearchTools(manager *ToolsetManager, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	index := NewToolIndex(toolsetGroup)
+	return mcp.NewTool("search_tools",
+			mcp.WithDescription(t("TOOL_SEARCH_TOOLS_DESCRIPTION", "Search all tools the GitHub MCP server can offer, including those of toolsets that are not enabled, by describing the task. Set enable to enable the toolsets of the matching tools in the same step")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title: t("TOOL_SEARCH_TOOLS_USER_TITLE", "Search tools"),
+				// Not modifying GitHub data so no need to show a warning
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Keywords describing the task, e.g. 'merge pull request' or 'workflow run logs'"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description(fmt.Sprintf("Maximum number of tools to return (default %d, max %d)", DefaultToolSearchLimit, MaxToolSearchLimit)),
+				mcp.Min(1),
+				mcp.Max(MaxToolSearchLimit),
+			),
+			mcp.WithBoolean("enable",
+				mcp.Description("Enable the toolsets of the returned tools"),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", DefaultToolSearchLimit)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit < 1 || limit > MaxToolSearchLimit {
+				return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", MaxToolSearchLimit)), nil
+			}
+			enable, err := OptionalParam[bool](request, "enable")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			response := toolSearchResponse{Results: index.Search(query, limit)}
+			if enable {
+				seen := make(map[string]bool)
+				for _, result := range response.Results {
+					if seen[result.Toolset] || toolsetGroup.IsEnabled(result.Toolset) {
+						continue
+					}
+					seen[result.Toolset] = true
+					// caution: this currently affects the global tools and notifies all clients
+					disabled, err := manager.Enable(result.Toolset)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					response.EnabledToolsets = append(response.EnabledToolsets, result.Toolset)
+					response.DisabledToolsets = append(response.DisabledToolsets, disabled...)
+				}
+			}
+			for i := range response.Results {
+				response.Results[i].ToolsetEnabled = toolsetGroup.IsEnabled(response.Results[i].Toolset)
+			}
+
+			return MarshalledTextResult(response), nil
+		}
+}
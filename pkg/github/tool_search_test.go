@@ -0,0 +1,90 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tokenize(t *testing.T) {
+	assert.Equal(t, []string{"list", "issue", "for", "a", "repo"}, tokenize("list_issues for a Repo"))
+	assert.Equal(t, []string{"get", "access", "status"}, tokenize("get-access status"))
+}
+
+func Test_ToolIndex_Search(t *testing.T) {
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "GitHub Issues related tools").
+		AddReadTools(okTool("list_issues"), okTool("get_issue")))
+	tsg.AddToolset(toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
+		AddWriteTools(server.ServerTool{Tool: mcp.NewTool("merge_pull_request",
+			mcp.WithDescription("Merge a pull request in a GitHub repository"),
+			mcp.WithString("merge_method", mcp.Description("Merge method")),
+		)}))
+
+	index := NewToolIndex(tsg)
+
+	results := index.Search("merge a pull request", 5)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "merge_pull_request", results[0].Name)
+	assert.Equal(t, "pull_requests", results[0].Toolset)
+
+	results = index.Search("list issue", 1)
+	require.Len(t, results, 1)
+	assert.Equal(t, "list_issues", results[0].Name)
+
+	assert.Empty(t, index.Search("kubernetes", 5))
+}
+
+func Test_SearchTools(t *testing.T) {
+	tsg := toolsets.NewToolsetGroup(false)
+	tool, _ := SearchTools(NewToolsetManager(server.NewMCPServer("test", "1.0.0"), tsg, 0), tsg, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_tools", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.Contains(t, tool.InputSchema.Properties, "enable")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+
+	t.Run("search without enabling", func(t *testing.T) {
+		s, tsg, dynamic := newDynamicTestServer(t, 0)
+		result := callDynamicTool(t, dynamic, "search_tools", map[string]any{"query": "workflows"})
+		require.False(t, result.IsError)
+
+		var response toolSearchResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, "list_workflows", response.Results[0].Name)
+		assert.False(t, response.Results[0].ToolsetEnabled)
+		assert.Empty(t, response.EnabledToolsets)
+		assert.False(t, tsg.Toolsets["actions"].Enabled)
+		assert.NotContains(t, registeredToolNames(t, s), "list_workflows")
+	})
+
+	t.Run("search and enable", func(t *testing.T) {
+		s, tsg, dynamic := newDynamicTestServer(t, 0)
+		result := callDynamicTool(t, dynamic, "search_tools", map[string]any{"query": "workflows", "enable": true})
+		require.False(t, result.IsError)
+
+		var response toolSearchResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, []string{"actions"}, response.EnabledToolsets)
+		assert.True(t, response.Results[0].ToolsetEnabled)
+		assert.True(t, tsg.Toolsets["actions"].Enabled)
+		assert.Contains(t, registeredToolNames(t, s), "list_workflows")
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, _, dynamic := newDynamicTestServer(t, 0)
+		result := callDynamicTool(t, dynamic, "search_tools", map[string]any{"query": "issues", "limit": float64(100)})
+		require.True(t, result.IsError)
+		assert.Equal(t, "limit must be between 1 and 25", getErrorResult(t, result).Text)
+	})
+}
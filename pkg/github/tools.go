@@ -16,7 +16,7 @@ type GetGQLClientFn func(context.Context) (*githubv4.Client, error)
 
 var DefaultTools = []string{"all"}
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int, repoChecker *RepoPermissionChecker) *toolsets.ToolsetGroup {
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int, repoChecker *RepoPermissionChecker, outputFormat OutputFormat, idempotencyStore *IdempotencyStore, tokenAccess *TokenAccess, repoContext *RepositoryContext) *toolsets.ToolsetGroup {
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
 	// Create toolsets - all tools use permission checking (null-safe when repoChecker is nil)
@@ -24,24 +24,47 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContentsWithPermissionCheck(getClient, getRawClient, t, repoChecker)),
+			toolsets.NewServerTool(GetRepositoryTreeWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(GetFileBlameWithPermissionCheck(getGQLClient, t, repoChecker)),
 			toolsets.NewServerTool(ListCommitsWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
 			toolsets.NewServerTool(GetCommitWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(CompareRefsWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(ListBranchesWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(ListTagsWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(GetTagWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(ListReleasesWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(GetLatestReleaseWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(GetReleaseByTagWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(GenerateReleaseNotesWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(GetBranchProtectionWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(GetBranchRulesWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(ListRulesetsWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(GetRulesetWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(ListRepositoryCollaboratorsWithPermissionCheck(getClient, t, repoChecker)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFileWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(CreateRepositoryWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(ForkRepositoryWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(RenameRepositoryWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(UpdateRepositoryWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(CreateBranchWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(PushFilesWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(CommitChangesWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(ApplyPatchWithPermissionCheck(getClient, t, repoChecker)),
 			toolsets.NewServerTool(DeleteFileWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(CreateReleaseWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(UpdateReleaseWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(DeleteReleaseWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(UploadReleaseAssetWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(CreateTagWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(DeleteTagWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(CreateRulesetWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(UpdateRulesetWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(AddCollaboratorWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(RemoveCollaboratorWithPermissionCheck(getClient, t, repoChecker)),
+			toolsets.NewServerTool(SetTeamRepositoryPermissionWithPermissionCheck(getClient, t, repoChecker)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -173,7 +196,7 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 
 	contextTools := toolsets.NewToolset("context", "Tools that provide context about the current user and GitHub context you are operating in").
 		AddReadTools(
-			toolsets.NewServerTool(GetMe(getClient, t)),
+			toolsets.NewServerTool(GetMe(getClient, t, tokenAccess)),
 			toolsets.NewServerTool(GetTeams(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(GetTeamMembers(getGQLClient, t)),
 		)
@@ -204,18 +227,57 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(gists)
 	tsg.AddToolset(securityAdvisories)
 
+	// tokenAccess is only set when hiding unusable tools is enabled, the tools the token cannot use are then not offered
+	HideUnusableTools(tsg, tokenAccess)
+	// The descriptions of the remaining tools list the scopes they require
+	AnnotateRequiredScopes(tsg)
+
+	// Errors of GitHub requests are returned as classified error results rather than protocol errors
+	tsg.MapTools(ErrorResultTool)
+	// Create-style tools accept an "idempotency_key" so that retries do not create duplicates
+	if idempotencyStore == nil {
+		idempotencyStore, _ = NewIdempotencyStore("")
+	}
+	tsg.MapTools(IdempotentTool(idempotencyStore))
+	// owner and repo default to the repository context of the session
+	if repoContext == nil {
+		repoContext = NewRepositoryContext()
+	}
+	tsg.MapTools(RepositoryContextTool(repoContext))
+	// All read tools share the "fields" parameter to trim their results
+	tsg.MapReadTools(FieldProjectionTool)
+	// and the "output_format" parameter, which renders the already projected results
+	tsg.MapReadTools(OutputFormatTool(outputFormat))
+
+	// batch_read is added last, the calls it dispatches already apply "fields" and "output_format"
+	contextTools.AddReadTools(
+		toolsets.NewServerTool(SetRepositoryContext(getClient, repoContext, t)),
+		toolsets.NewServerTool(GetRepositoryContext(repoContext, t)),
+		toolsets.NewServerTool(ListWorkspaceRepositories(repoContext, t)),
+		toolsets.NewServerTool(BatchRead(tsg, t)),
+	)
+
 	return tsg
 }
 
-// InitDynamicToolset creates a dynamic toolset that can be used to enable other toolsets, and so requires the server and toolset group as arguments
-func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) *toolsets.Toolset {
+// InitDynamicToolset creates a dynamic toolset that can be used to enable and disable other toolsets, and so requires the server and toolset group as arguments.
+// When maxActiveTools is positive, the least recently used toolsets are disabled to keep the number of active tools within it.
+// It must be called before the tools of the toolset group are registered, so that their use is tracked.
+func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc, maxActiveTools int) *toolsets.Toolset {
+	manager := NewToolsetManager(s, tsg, maxActiveTools)
+	for name, toolset := range tsg.Toolsets {
+		toolset.MapTools(manager.TrackUsage(name))
+	}
+
 	// Create a new dynamic toolset
 	// Need to add the dynamic toolset last so it can be used to enable other toolsets
 	dynamicToolSelection := toolsets.NewToolset("dynamic", "Discover GitHub MCP tools that can help achieve tasks by enabling additional sets of tools, you can control the enablement of any toolset to access its tools when this toolset is enabled.").
 		AddReadTools(
 			toolsets.NewServerTool(ListAvailableToolsets(tsg, t)),
 			toolsets.NewServerTool(GetToolsetsTools(tsg, t)),
-			toolsets.NewServerTool(EnableToolset(s, tsg, t)),
+			toolsets.NewServerTool(SearchTools(manager, tsg, t)),
+			toolsets.NewServerTool(EnableToolset(manager, tsg, t)),
+			toolsets.NewServerTool(DisableToolset(manager, tsg, t)),
 		)
 
 	dynamicToolSelection.Enabled = true
@@ -0,0 +1,196 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// WorkspaceRepository is a repository found in the git remotes of a workspace root of the client.
+type WorkspaceRepository struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Remote string `json:"remote"`
+	Root   string `json:"root"`
+}
+
+var (
+	remoteSectionPattern = regexp.MustCompile(`^\[remote\s+"([^"]+)"\]$`)
+	// scpRemotePattern matches the scp-like syntax of ssh remotes, e.g. git@github.com:owner/repo.git
+	scpRemotePattern = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+)
+
+// DetectWorkspaceRepositories finds the repositories on gitHost, e.g. github.com, that the git
+// remotes of the given roots point to. Roots that are not local directories inside a git
+// repository are skipped. The remote "origin" of each root is listed first.
+func DetectWorkspaceRepositories(gitHost string, roots []mcp.Root) []WorkspaceRepository {
+	var repos []WorkspaceRepository
+	for _, root := range roots {
+		u, err := url.Parse(root.URI)
+		if err != nil || u.Scheme != "file" {
+			continue
+		}
+		dir := filepath.FromSlash(u.Path)
+		configPath := findGitConfig(dir)
+		if configPath == "" {
+			continue
+		}
+		remotes, err := readGitRemotes(configPath)
+		if err != nil {
+			continue
+		}
+
+		var rootRepos []WorkspaceRepository
+		for _, remote := range remotes {
+			ref, ok := ParseGitRemoteURL(remote.url, gitHost)
+			if !ok {
+				continue
+			}
+			repo := WorkspaceRepository{Owner: ref.Owner, Repo: ref.Repo, Remote: remote.name, Root: dir}
+			if remote.name == "origin" {
+				rootRepos = append([]WorkspaceRepository{repo}, rootRepos...)
+			} else {
+				rootRepos = append(rootRepos, repo)
+			}
+		}
+		repos = append(repos, rootRepos...)
+	}
+	return repos
+}
+
+// ParseGitRemoteURL returns the repository a git remote URL points to, if it is on gitHost.
+// Both URLs, e.g. https://github.com/owner/repo.git, and the scp-like syntax of ssh remotes,
+// e.g. git@github.com:owner/repo.git, are supported.
+func ParseGitRemoteURL(remoteURL, gitHost string) (RepositoryRef, bool) {
+	var host, repoPath string
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return RepositoryRef{}, false
+		}
+		host, repoPath = u.Hostname(), u.Path
+	} else if match := scpRemotePattern.FindStringSubmatch(remoteURL); match != nil {
+		host, repoPath = match[1], match[2]
+	} else {
+		return RepositoryRef{}, false
+	}
+
+	// ssh.github.com serves ssh over port 443 for github.com
+	if !strings.EqualFold(host, gitHost) && !strings.EqualFold(host, "ssh."+gitHost) {
+		return RepositoryRef{}, false
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	parts := strings.Split(repoPath, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return RepositoryRef{}, false
+	}
+	return RepositoryRef{Owner: parts[0], Repo: parts[1]}, true
+}
+
+// findGitConfig returns the path of the git config of the repository containing dir, or an
+// empty string if dir is not inside a git repository.
+func findGitConfig(dir string) string {
+	for {
+		gitPath := filepath.Join(dir, ".git")
+		info, err := os.Stat(gitPath)
+		if err == nil {
+			if info.IsDir() {
+				return filepath.Join(gitPath, "config")
+			}
+			// Worktrees and submodules have a .git file pointing to the git directory
+			return gitConfigFromGitFile(dir, gitPath)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func gitConfigFromGitFile(dir, gitFile string) string {
+	data, err := os.ReadFile(gitFile) //nolint:gosec // the path is within a root provided by the client
+	if err != nil {
+		return ""
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+	// Linked worktrees share the config of the main repository
+	if commonDir, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil { //nolint:gosec // see above
+		common := strings.TrimSpace(string(commonDir))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		gitDir = common
+	}
+	return filepath.Join(gitDir, "config")
+}
+
+type gitRemote struct {
+	name string
+	url  string
+}
+
+// readGitRemotes reads the remotes from a git config file, in the order they are defined.
+func readGitRemotes(configPath string) ([]gitRemote, error) {
+	f, err := os.Open(configPath) //nolint:gosec // the path is within a root provided by the client
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var remotes []gitRemote
+	current := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			current = ""
+			if match := remoteSectionPattern.FindStringSubmatch(line); match != nil {
+				current = match[1]
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "url") {
+			remotes = append(remotes, gitRemote{name: current, url: strings.TrimSpace(value)})
+		}
+	}
+	return remotes, scanner.Err()
+}
+
+// ListWorkspaceRepositories creates a tool to list the repositories found in the workspace roots of the client.
+func ListWorkspaceRepositories(repoContext *RepositoryContext, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_workspace_repositories",
+			mcp.WithDescription(t("TOOL_LIST_WORKSPACE_REPOSITORIES_DESCRIPTION", "List the GitHub repositories of the local git checkouts in the workspace of the client, based on their git remotes. The first one is the default when owner and repo are omitted and no repository context is set")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WORKSPACE_REPOSITORIES_USER_TITLE", "List workspace repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			repos := repoContext.WorkspaceRepositories()
+			if len(repos) == 0 {
+				return mcp.NewToolResultText("No GitHub repositories were found in the workspace roots of the client"), nil
+			}
+			return MarshalledTextResult(repos), nil
+		}
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseGitRemoteURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		host     string
+		expected RepositoryRef
+		ok       bool
+	}{
+		{url: "https://github.com/octocat/hello-world.git", host: "github.com", expected: RepositoryRef{Owner: "octocat", Repo: "hello-world"}, ok: true},
+		{url: "https://github.com/octocat/hello-world", host: "github.com", expected: RepositoryRef{Owner: "octocat", Repo: "hello-world"}, ok: true},
+		{url: "git@github.com:octocat/hello-world.git", host: "github.com", expected: RepositoryRef{Owner: "octocat", Repo: "hello-world"}, ok: true},
+		{url: "ssh://git@ssh.github.com:443/octocat/hello-world.git", host: "github.com", expected: RepositoryRef{Owner: "octocat", Repo: "hello-world"}, ok: true},
+		{url: "https://github.example.com/octocat/hello-world.git", host: "github.example.com", expected: RepositoryRef{Owner: "octocat", Repo: "hello-world"}, ok: true},
+		{url: "https://gitlab.com/octocat/hello-world.git", host: "github.com"},
+		{url: "https://github.com/octocat", host: "github.com"},
+		{url: "/srv/git/hello-world.git", host: "github.com"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			ref, ok := ParseGitRemoteURL(tc.url, tc.host)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func Test_DetectWorkspaceRepositories(t *testing.T) {
+	dir := t.TempDir()
+
+	// A checkout with an upstream remote defined before origin
+	repo := filepath.Join(dir, "hello-world")
+	writeFile(t, filepath.Join(repo, ".git", "config"), `[core]
+	bare = false
+[remote "upstream"]
+	url = https://github.com/github/hello-world.git
+	fetch = +refs/heads/*:refs/remotes/upstream/*
+[remote "origin"]
+	url = git@github.com:octocat/hello-world.git
+[remote "mirror"]
+	url = https://gitlab.com/octocat/hello-world.git
+`)
+	// A linked worktree of the checkout, opened at a subdirectory
+	worktree := filepath.Join(dir, "feature")
+	writeFile(t, filepath.Join(repo, ".git", "worktrees", "feature", "commondir"), "../..\n")
+	writeFile(t, filepath.Join(worktree, ".git"), "gitdir: "+filepath.Join(repo, ".git", "worktrees", "feature")+"\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(worktree, "src"), 0o755))
+	// A directory that is not a checkout
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "notes"), 0o755))
+
+	repos := DetectWorkspaceRepositories("github.com", []mcp.Root{
+		{URI: "file://" + filepath.ToSlash(repo)},
+		{URI: "file://" + filepath.ToSlash(filepath.Join(worktree, "src"))},
+		{URI: "file://" + filepath.ToSlash(filepath.Join(dir, "notes"))},
+		{URI: "https://example.com/not-a-file"},
+	})
+
+	worktreeSrc := filepath.Join(worktree, "src")
+	assert.Equal(t, []WorkspaceRepository{
+		{Owner: "octocat", Repo: "hello-world", Remote: "origin", Root: repo},
+		{Owner: "github", Repo: "hello-world", Remote: "upstream", Root: repo},
+		{Owner: "octocat", Repo: "hello-world", Remote: "origin", Root: worktreeSrc},
+		{Owner: "github", Repo: "hello-world", Remote: "upstream", Root: worktreeSrc},
+	}, repos)
+}
+
+func Test_ListWorkspaceRepositories(t *testing.T) {
+	repoContext := NewRepositoryContext()
+	tool, handler := ListWorkspaceRepositories(repoContext, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, "No GitHub repositories were found")
+
+	repos := []WorkspaceRepository{{Owner: "octocat", Repo: "hello-world", Remote: "origin", Root: "/work/hello-world"}}
+	repoContext.SetWorkspaceRepositories(repos)
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	var listed []WorkspaceRepository
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &listed))
+	assert.Equal(t, repos, listed)
+
+	t.Run("workspace repository is the default", func(t *testing.T) {
+		_, getHandler := GetRepositoryContext(repoContext, translations.NullTranslationHelper)
+		result, err := getHandler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"owner":"octocat","repo":"hello-world","source":"workspace"}`, getTextResult(t, result).Text)
+
+		// A repository set for the session takes precedence
+		repoContext.Set(context.Background(), RepositoryRef{Owner: "github", Repo: "docs"})
+		ref, ok := repoContext.Get(context.Background())
+		require.True(t, ok)
+		assert.Equal(t, RepositoryRef{Owner: "github", Repo: "docs"}, ref)
+	})
+}
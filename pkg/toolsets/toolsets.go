@@ -2,6 +2,8 @@ package toolsets
 
 import (
 	"fmt"
+	"path"
+	"sync"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -60,14 +62,13 @@ type Toolset struct {
 	resourceTemplates []server.ServerResourceTemplate
 	// prompts are also not tools but are namespaced similarly
 	prompts []server.ServerPrompt
+	// excludedTools are the names of the tools removed by the tool filters of the group
+	excludedTools []string
 }
 
 func (t *Toolset) GetActiveTools() []server.ServerTool {
 	if t.Enabled {
-		if t.readOnly {
-			return t.readTools
-		}
-		return append(t.readTools, t.writeTools...)
+		return t.GetAvailableTools()
 	}
 	return nil
 }
@@ -76,7 +77,8 @@ func (t *Toolset) GetAvailableTools() []server.ServerTool {
 	if t.readOnly {
 		return t.readTools
 	}
-	return append(t.readTools, t.writeTools...)
+	// Copy the read tools, so that callers never share the backing array of the toolset
+	return append(append([]server.ServerTool{}, t.readTools...), t.writeTools...)
 }
 
 func (t *Toolset) RegisterTools(s *server.MCPServer) {
@@ -160,10 +162,50 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 	return t
 }
 
+// MapReadTools replaces each read tool in the toolset with the result of fn,
+// allowing shared parameters and behaviour to be layered onto existing tools.
+func (t *Toolset) MapReadTools(fn func(server.ServerTool) server.ServerTool) *Toolset {
+	for i, tool := range t.readTools {
+		t.readTools[i] = fn(tool)
+	}
+	return t
+}
+
+// MapTools replaces each read and write tool in the toolset with the result of fn.
+func (t *Toolset) MapTools(fn func(server.ServerTool) server.ServerTool) *Toolset {
+	for i, tool := range t.writeTools {
+		t.writeTools[i] = fn(tool)
+	}
+	return t.MapReadTools(fn)
+}
+
+// RemoveTools removes the read and write tools for which remove returns true.
+func (t *Toolset) RemoveTools(remove func(server.ServerTool) bool) *Toolset {
+	keep := func(tools []server.ServerTool) []server.ServerTool {
+		kept := tools[:0]
+		for _, tool := range tools {
+			if !remove(tool) {
+				kept = append(kept, tool)
+			}
+		}
+		return kept
+	}
+	t.readTools = keep(t.readTools)
+	t.writeTools = keep(t.writeTools)
+	return t
+}
+
+// ExcludedTools returns the names of the tools of the toolset that were removed by FilterTools.
+func (t *Toolset) ExcludedTools() []string {
+	return t.excludedTools
+}
+
 type ToolsetGroup struct {
 	Toolsets     map[string]*Toolset
 	everythingOn bool
 	readOnly     bool
+	// mu guards the Enabled state of the toolsets, which can change while the server runs in dynamic mode
+	mu sync.RWMutex
 }
 
 func NewToolsetGroup(readOnly bool) *ToolsetGroup {
@@ -196,6 +238,8 @@ func (tg *ToolsetGroup) IsEnabled(name string) bool {
 		return true
 	}
 
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
 	feature, exists := tg.Toolsets[name]
 	if !exists {
 		return false
@@ -203,6 +247,17 @@ func (tg *ToolsetGroup) IsEnabled(name string) bool {
 	return feature.Enabled
 }
 
+// ActiveTools returns the tools of all enabled toolsets.
+func (tg *ToolsetGroup) ActiveTools() []server.ServerTool {
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
+	var tools []server.ServerTool
+	for _, toolset := range tg.Toolsets {
+		tools = append(tools, toolset.GetActiveTools()...)
+	}
+	return tools
+}
+
 func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 	// Special case for "all"
 	for _, name := range names {
@@ -229,12 +284,68 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 }
 
 func (tg *ToolsetGroup) EnableToolset(name string) error {
+	return tg.SetEnabled(name, true)
+}
+
+// SetEnabled enables or disables a toolset. Use it rather than setting Enabled once the server
+// runs, so that concurrent readers of the group see a consistent state.
+func (tg *ToolsetGroup) SetEnabled(name string, enabled bool) error {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
 		return NewToolsetDoesNotExistError(name)
 	}
-	toolset.Enabled = true
-	tg.Toolsets[name] = toolset
+	toolset.Enabled = enabled
+	return nil
+}
+
+// MapReadTools applies fn to the read tools of every toolset in the group.
+func (tg *ToolsetGroup) MapReadTools(fn func(server.ServerTool) server.ServerTool) {
+	for _, toolset := range tg.Toolsets {
+		toolset.MapReadTools(fn)
+	}
+}
+
+// MapTools applies fn to the read and write tools of every toolset in the group.
+func (tg *ToolsetGroup) MapTools(fn func(server.ServerTool) server.ServerTool) {
+	for _, toolset := range tg.Toolsets {
+		toolset.MapTools(fn)
+	}
+}
+
+// FilterTools removes the tools whose names match none of the include patterns, when include is
+// not empty, or match any of the exclude patterns, from every toolset in the group. Patterns use
+// path.Match syntax, e.g. "get_*". Since the tools are removed from the toolsets, the filters also
+// apply to toolsets that are enabled later on.
+func (tg *ToolsetGroup) FilterTools(include, exclude []string) error {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+	}
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+
+	matchesAny := func(patterns []string, name string) bool {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+		return false
+	}
+	for _, toolset := range tg.Toolsets {
+		toolset.RemoveTools(func(tool server.ServerTool) bool {
+			name := tool.Tool.Name
+			excluded := (len(include) > 0 && !matchesAny(include, name)) || matchesAny(exclude, name)
+			if excluded {
+				toolset.excludedTools = append(toolset.excludedTools, name)
+			}
+			return excluded
+		})
+	}
 	return nil
 }
 
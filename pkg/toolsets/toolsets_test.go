@@ -3,6 +3,9 @@ package toolsets
 import (
 	"errors"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -250,3 +253,129 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func TestMapReadTools(t *testing.T) {
+	readTool := server.ServerTool{Tool: mcp.NewTool("read", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: boolPtr(true)}))}
+	writeTool := server.ServerTool{Tool: mcp.NewTool("write", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: boolPtr(false)}))}
+
+	toolset := NewToolset("test-toolset", "A test toolset").
+		AddReadTools(readTool).
+		AddWriteTools(writeTool)
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(toolset)
+
+	tsg.MapReadTools(func(st server.ServerTool) server.ServerTool {
+		st.Tool.Description = "mapped"
+		return st
+	})
+
+	toolset.Enabled = true
+	for _, tool := range toolset.GetActiveTools() {
+		switch tool.Tool.Name {
+		case "read":
+			if tool.Tool.Description != "mapped" {
+				t.Errorf("Expected read tool to be mapped, got description '%s'", tool.Tool.Description)
+			}
+		case "write":
+			if tool.Tool.Description == "mapped" {
+				t.Error("Expected write tool not to be mapped")
+			}
+		}
+	}
+}
+
+func TestFilterTools(t *testing.T) {
+	newTool := func(name string, readOnly bool) server.ServerTool {
+		return server.ServerTool{Tool: mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: boolPtr(readOnly)}))}
+	}
+	newGroup := func() *ToolsetGroup {
+		tsg := NewToolsetGroup(false)
+		tsg.AddToolset(NewToolset("repos", "Repositories").
+			AddReadTools(newTool("get_file_contents", true), newTool("list_branches", true)).
+			AddWriteTools(newTool("create_branch", false), newTool("push_files", false), newTool("delete_file", false)))
+		return tsg
+	}
+	availableNames := func(toolset *Toolset) []string {
+		var names []string
+		for _, tool := range toolset.GetAvailableTools() {
+			names = append(names, tool.Tool.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+		excluded []string
+	}{
+		{
+			name:     "no filters",
+			expected: []string{"get_file_contents", "list_branches", "create_branch", "push_files", "delete_file"},
+		},
+		{
+			name:     "include with globs",
+			include:  []string{"get_*", "list_*", "create_branch", "push_files"},
+			expected: []string{"get_file_contents", "list_branches", "create_branch", "push_files"},
+			excluded: []string{"delete_file"},
+		},
+		{
+			name:     "exclude",
+			exclude:  []string{"delete_*"},
+			expected: []string{"get_file_contents", "list_branches", "create_branch", "push_files"},
+			excluded: []string{"delete_file"},
+		},
+		{
+			name:     "exclude wins over include",
+			include:  []string{"*_branch*"},
+			exclude:  []string{"create_*"},
+			expected: []string{"list_branches"},
+			excluded: []string{"get_file_contents", "create_branch", "push_files", "delete_file"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsg := newGroup()
+			if err := tsg.FilterTools(tc.include, tc.exclude); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			toolset := tsg.Toolsets["repos"]
+			if got := availableNames(toolset); !equalNames(got, tc.expected) {
+				t.Errorf("Expected tools %v, got %v", tc.expected, got)
+			}
+			if got := toolset.ExcludedTools(); !equalNames(got, tc.excluded) {
+				t.Errorf("Expected excluded tools %v, got %v", tc.excluded, got)
+			}
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		if err := newGroup().FilterTools([]string{"get_["}, nil); err == nil {
+			t.Error("Expected error for invalid pattern")
+		}
+	})
+}
+
+// equalNames reports whether two lists contain the same names, ignoring order.
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int)
+	for _, name := range a {
+		counts[name]++
+	}
+	for _, name := range b {
+		counts[name]--
+		if counts[name] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}